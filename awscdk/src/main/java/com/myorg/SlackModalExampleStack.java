@@ -1,9 +1,20 @@
 package com.myorg;
 
+import java.util.Arrays;
+import java.util.HashMap;
+import java.util.Map;
+
+import software.amazon.awscdk.core.ArnComponents;
 import software.amazon.awscdk.core.Construct;
+import software.amazon.awscdk.core.RemovalPolicy;
 import software.amazon.awscdk.core.Stack;
 import software.amazon.awscdk.core.StackProps;
 import software.amazon.awscdk.services.apigateway.LambdaRestApi;
+import software.amazon.awscdk.services.dynamodb.Attribute;
+import software.amazon.awscdk.services.dynamodb.AttributeType;
+import software.amazon.awscdk.services.dynamodb.BillingMode;
+import software.amazon.awscdk.services.dynamodb.Table;
+import software.amazon.awscdk.services.iam.PolicyStatement;
 import software.amazon.awscdk.services.lambda.Code;
 import software.amazon.awscdk.services.lambda.Function;
 import software.amazon.awscdk.services.lambda.Runtime;
@@ -16,19 +27,50 @@ public class SlackModalExampleStack extends Stack {
     public SlackModalExampleStack(final Construct scope, final String id, final StackProps props) {
         super(scope, id, props);
 
+        // Environment variables - settings of the handlers
+        // Pass them as context values: cdk deploy -c signingSecret=... -c botToken=...
+        final Map<String, String> environment = new HashMap<>();
+        putContext(environment, "SLACK_SIGNING_SECRET", "signingSecret");
+        putContext(environment, "SLACK_BOT_TOKEN", "botToken");
+
         // Lamnda - event handler
         final Function eventLambda = Function.Builder.create(this, "EventHandler")
             .runtime(Runtime.GO_1_X)
             .code(Code.fromAsset("../go_event_message/bin"))
             .handler("main")
+            .environment(environment)
+            .build();
+
+        // DynamoDB - orders in progress, shared by the containers of the interactive handler
+        final Table sessionTable = Table.Builder.create(this, "SessionTable")
+            .partitionKey(Attribute.builder().name("key").type(AttributeType.STRING).build())
+            .timeToLiveAttribute("expires_at")
+            .billingMode(BillingMode.PAY_PER_REQUEST)
+            .removalPolicy(RemovalPolicy.DESTROY)
             .build();
+        final Map<String, String> interactiveEnvironment = new HashMap<>(environment);
+        interactiveEnvironment.put("SESSION_TABLE", sessionTable.getTableName());
 
         // Lamnda - interactive handler
         final Function interactiveLambda = Function.Builder.create(this, "InteractiveHandler")
             .runtime(Runtime.GO_1_X)
             .code(Code.fromAsset("../go_interactive_message/bin"))
             .handler("main")
+            .environment(interactiveEnvironment)
             .build();
+        sessionTable.grantReadWriteData(interactiveLambda);
+
+        // The interactive handler invokes itself to run slow work after acknowledging a request.
+        // Refer to the function by a name pattern, because referring to its ARN makes a circular dependency.
+        interactiveLambda.addToRolePolicy(PolicyStatement.Builder.create()
+            .actions(Arrays.asList("lambda:InvokeFunction"))
+            .resources(Arrays.asList(this.formatArn(ArnComponents.builder()
+                .service("lambda")
+                .resource("function")
+                .sep(":")
+                .resourceName(this.getStackName() + "-InteractiveHandler*")
+                .build())))
+            .build());
 
         // API Gateway
         LambdaRestApi.Builder.create(this, "SlackExampleEventEndpoint")
@@ -39,4 +81,11 @@ public class SlackModalExampleStack extends Stack {
             .handler(interactiveLambda)
             .build();
     }
+
+    private void putContext(final Map<String, String> environment, final String name, final String contextKey) {
+        final Object value = this.getNode().tryGetContext(contextKey);
+        if (value != null) {
+            environment.put(name, value.toString());
+        }
+    }
 }
@@ -0,0 +1,143 @@
+// Package eventapp handles requests from the Slack Events API.
+// It receives a message to call a bot and sends an interactive message with buttons.
+package eventapp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/nicoJN/slack-modal-examples/slackapp"
+	"github.com/nicoJN/slack-modal-examples/slackapp/catalog"
+	"github.com/nicoJN/slack-modal-examples/slackapp/config"
+	"github.com/nicoJN/slack-modal-examples/slackapp/idempotency"
+	"github.com/nicoJN/slack-modal-examples/slackapp/logging"
+	"github.com/nicoJN/slack-modal-examples/slackapp/slackapi"
+	"github.com/nlopes/slack/slackevents"
+	"github.com/slack-go/slack"
+)
+
+// App handles requests from the Slack Events API.
+type App struct {
+	api      slackapi.Client
+	catalog  *catalog.Catalog
+	events   idempotency.Store
+	reporter *slackapp.ErrorReporter
+	handler  slackapp.Handler
+}
+
+// Option configures an App.
+type Option func(*App)
+
+// WithSlackClient replaces the Slack Web API client, e.g. with a fake in tests.
+func WithSlackClient(api slackapi.Client) Option {
+	return func(a *App) {
+		a.api = api
+	}
+}
+
+// WithEventStore replaces the store of handled event IDs, which skips Events API retries.
+func WithEventStore(store idempotency.Store) Option {
+	return func(a *App) {
+		a.events = store
+	}
+}
+
+// New returns an App configured by cfg, which lists the shops in cat.
+func New(cfg *config.Config, cat *catalog.Catalog, opts ...Option) *App {
+	a := &App{
+		api:     slackapi.New(cfg.BotToken),
+		catalog: cat,
+		events:  idempotency.NewMemoryStore(),
+	}
+	if cfg.EventIDFile != "" {
+		a.events = idempotency.NewFileStore(cfg.EventIDFile)
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	a.reporter = slackapp.NewErrorReporter(a.api)
+
+	// Slack retries an event when it isn't acknowledged in time, so handle each event once.
+	middlewares := append(slackapp.Standard(cfg.SigningSecret), slackapp.DeduplicateEvents(a.events, slackapp.EventTTL))
+	a.handler = slackapp.Chain(a.handleEventRequest, middlewares...)
+	return a
+}
+
+// HandleEventRequest handles a request from the Slack Events API.
+// The request is verified by the middlewares before it's handled.
+func (a *App) HandleEventRequest(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	return a.handler(ctx, request)
+}
+
+func (a *App) handleEventRequest(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	body := json.RawMessage(request.Body)
+
+	// Parse event.
+	eventsAPIEvent, err := slackevents.ParseEvent(body, slackevents.OptionNoVerifyToken())
+	if err != nil {
+		return slackapp.AckError(ctx, "Failed to parse request body", err)
+	}
+
+	// Check if the request type is URL Verification. This logic is only called from slack developer's console when you set up your app.
+	if eventsAPIEvent.Type == slackevents.URLVerification {
+		var r *slackevents.ChallengeResponse
+		if err := json.Unmarshal(body, &r); err != nil {
+			return slackapp.AckError(ctx, "Failed to unmarshal json", err)
+		}
+		return slackapp.Text(r.Challenge), nil
+	}
+
+	// Verify the request type.
+	if eventsAPIEvent.Type != slackevents.CallbackEvent {
+		logging.FromContext(ctx).Error("Unexpected event type", "expect", slackevents.CallbackEvent, "actual", eventsAPIEvent.Type)
+		return slackapp.OK(), nil
+	}
+
+	ctx = logging.With(ctx, logging.KeyTeamID, eventsAPIEvent.TeamID)
+
+	// Verify the event type.
+	switch ev := eventsAPIEvent.InnerEvent.Data.(type) {
+	case *slackevents.AppMentionEvent:
+		ctx = logging.With(ctx, logging.KeyUserID, ev.User, logging.KeyChannelID, ev.Channel)
+
+		// Create a shop list.
+		list := createShopListBySDK(a.catalog.Shops)
+
+		// Send a shop list to slack channel.
+		if _, _, err := a.api.PostMessageContext(ctx, ev.Channel, list); err != nil {
+			return a.reporter.ReportEvent(ctx, ev.Channel, ev.User, fmt.Errorf("failed to send a message to Slack: %w", err))
+		}
+
+	default:
+		return slackapp.OK(), nil
+	}
+
+	return slackapp.OK(), nil
+}
+
+// createShopListBySDK returns a message option which contains shop infomation.
+func createShopListBySDK(shops []catalog.Shop) slack.MsgOption {
+	// Top text
+	descText := slack.NewTextBlockObject("mrkdwn", "What do you want to have?", false, false)
+	descTextSection := slack.NewSectionBlock(descText, nil, nil)
+
+	// Divider
+	dividerBlock := slack.NewDividerBlock()
+
+	blocks := []slack.Block{descTextSection, dividerBlock}
+
+	// Shops
+	// - A section with an Order button. The button value tells the shop to the interactive handler.
+	for _, shop := range shops {
+		buttonText := slack.NewTextBlockObject("plain_text", "Order", true, false)
+		buttonElement := slack.NewButtonBlockElement(shop.ActionID, shop.ID, buttonText)
+		accessory := slack.NewAccessory(buttonElement)
+		sectionText := slack.NewTextBlockObject("mrkdwn", fmt.Sprintf("%s *%s*\n%s", shop.Emoji, shop.Name, shop.Description), false, false)
+		blocks = append(blocks, slack.NewSectionBlock(sectionText, nil, accessory))
+	}
+
+	// Blocks
+	return slack.MsgOptionBlocks(blocks...)
+}
@@ -0,0 +1,168 @@
+package eventapp
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/nicoJN/slack-modal-examples/slackapp/blockkit"
+	"github.com/nicoJN/slack-modal-examples/slackapp/catalog"
+	"github.com/nicoJN/slack-modal-examples/slackapp/config"
+	"github.com/nicoJN/slack-modal-examples/slackapp/slackapi/slackapitest"
+	"github.com/nicoJN/slack-modal-examples/slackapp/slacktest"
+	"github.com/slack-go/slack"
+)
+
+func newTestApp(t *testing.T) (*App, *slackapitest.Fake) {
+	t.Helper()
+
+	// The catalog shipped with the handlers.
+	cat, err := catalog.Load("../../catalog.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fake := slackapitest.NewFake()
+	cfg := &config.Config{SigningSecret: slacktest.SigningSecret, BotToken: "xoxb-test"}
+	return New(cfg, cat, WithSlackClient(fake)), fake
+}
+
+func TestHandleEventRequestAppMention(t *testing.T) {
+	a, fake := newTestApp(t)
+
+	request, err := slacktest.NewEventRequest(slacktest.SigningSecret, slacktest.AppMention("C0001", "U0001", "<@B0001> hungry"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := a.HandleEventRequest(context.Background(), request)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.StatusCode != 200 {
+		t.Errorf("status code = %d, want 200", res.StatusCode)
+	}
+
+	messages := fake.Messages()
+	if len(messages) != 1 {
+		t.Fatalf("posted %d messages, want 1", len(messages))
+	}
+	if messages[0].ChannelID != "C0001" {
+		t.Errorf("channel = %q, want C0001", messages[0].ChannelID)
+	}
+
+	texts := strings.Join(slacktest.Texts(messages[0].Blocks), "\n")
+	for _, shop := range []string{"Hungryman Hamburgers", "Ace Wasabi Rock-n-Roll Sushi Bar", "Sazanami Ramen"} {
+		if !strings.Contains(texts, shop) {
+			t.Errorf("shop list doesn't contain %q", shop)
+		}
+	}
+
+	var actionIDs []string
+	for _, b := range messages[0].Blocks.BlockSet {
+		if s, ok := b.(*slack.SectionBlock); ok && s.Accessory != nil && s.Accessory.ButtonElement != nil {
+			actionIDs = append(actionIDs, s.Accessory.ButtonElement.ActionID)
+		}
+	}
+	if got, want := strings.Join(actionIDs, ","), "actionIDHamburger,actionIDSushi,actionIDRamen"; got != want {
+		t.Errorf("action IDs = %s, want %s", got, want)
+	}
+}
+
+func TestShopListWithinLimits(t *testing.T) {
+	cat, err := catalog.Load("../../catalog.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := blockkit.ValidateMessage(createShopListBySDK(cat.Shops)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestHandleEventRequestURLVerification(t *testing.T) {
+	a, fake := newTestApp(t)
+
+	request := slacktest.NewRequest(slacktest.SigningSecret, `{"token":"test-token","challenge":"challenge-value","type":"url_verification"}`)
+	res, err := a.HandleEventRequest(context.Background(), request)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.Body != "challenge-value" {
+		t.Errorf("body = %q, want challenge-value", res.Body)
+	}
+	if n := len(fake.Messages()); n != 0 {
+		t.Errorf("posted %d messages, want 0", n)
+	}
+}
+
+func TestHandleEventRequestInvalidSignature(t *testing.T) {
+	a, fake := newTestApp(t)
+
+	request, err := slacktest.NewEventRequest("wrong-secret", slacktest.AppMention("C0001", "U0001", "hi"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := a.HandleEventRequest(context.Background(), request)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.StatusCode != 200 {
+		t.Errorf("status code = %d, want 200", res.StatusCode)
+	}
+	if n := len(fake.Messages()); n != 0 {
+		t.Errorf("posted %d messages, want 0", n)
+	}
+}
+
+func TestHandleEventRequestPostMessageFailure(t *testing.T) {
+	a, fake := newTestApp(t)
+	fake.PostMessageError = errors.New("channel_not_found")
+
+	request, err := slacktest.NewEventRequest(slacktest.SigningSecret, slacktest.AppMention("C0001", "U0001", "hi"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := a.HandleEventRequest(context.Background(), request)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.StatusCode != 200 {
+		t.Errorf("status code = %d, want 200", res.StatusCode)
+	}
+
+	ephemerals := fake.Ephemerals()
+	if len(ephemerals) != 1 {
+		t.Fatalf("posted %d ephemeral messages, want 1", len(ephemerals))
+	}
+	if e := ephemerals[0]; e.ChannelID != "C0001" || e.UserID != "U0001" || !strings.Contains(e.Text, "Reference") {
+		t.Errorf("ephemeral = %+v, want an error message to U0001 in C0001 with a reference", e)
+	}
+}
+
+func TestRetriedAppMention(t *testing.T) {
+	a, fake := newTestApp(t)
+
+	request, err := slacktest.NewEventRequest(slacktest.SigningSecret, slacktest.AppMention("C0001", "U0001", "<@B0001> hungry"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Slack redelivers the same event when the first delivery isn't acknowledged in time.
+	for i, reason := range []string{"", "http_timeout", "http_timeout"} {
+		if reason != "" {
+			request.Headers["X-Slack-Retry-Num"] = strconv.Itoa(i)
+			request.Headers["X-Slack-Retry-Reason"] = reason
+		}
+		if _, err := a.HandleEventRequest(context.Background(), request); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if n := len(fake.Messages()); n != 1 {
+		t.Errorf("posted %d messages, want 1", n)
+	}
+}
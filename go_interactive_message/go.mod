@@ -4,6 +4,10 @@ go 1.14
 
 require (
 	github.com/aws/aws-lambda-go v1.19.1
+	github.com/nicoJN/slack-modal-examples/slackapp v0.0.0
 	github.com/pkg/errors v0.9.1 // indirect
 	github.com/slack-go/slack v0.6.6
+	modernc.org/sqlite v1.10.6
 )
+
+replace github.com/nicoJN/slack-modal-examples/slackapp => ../slackapp
@@ -0,0 +1,159 @@
+package interactiveapp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/nicoJN/slack-modal-examples/slackapp"
+	"github.com/nicoJN/slack-modal-examples/slackapp/catalog"
+	"github.com/nicoJN/slack-modal-examples/slackapp/schema"
+	"github.com/slack-go/slack"
+)
+
+func (a *App) handleButtonPushedRequest(ctx context.Context, message slack.InteractionCallback) (events.APIGatewayProxyResponse, error) {
+	// Get selected value
+	if len(message.ActionCallback.BlockActions) == 0 {
+		return slackapp.OK(), fmt.Errorf("no actions in block_actions payload")
+	}
+
+	// Open the modal before acknowledging the request.
+	// NOTE: A trigger ID expires in 3 seconds, so views.open can't wait for a job. It's a single call,
+	// which fits in slackapp.AckTimeout.
+	return slackapp.OK(), a.openOrderModal(ctx, message)
+}
+
+func (a *App) openOrderModal(ctx context.Context, message slack.InteractionCallback) error {
+	shop, ok := a.catalog.Shop(message.ActionCallback.BlockActions[0].Value)
+	if !ok {
+		return fmt.Errorf("unknown shop: %s", message.ActionCallback.BlockActions[0].Value)
+	}
+
+	// Start an order with an empty cart. Its session is keyed by the external ID of the order modal.
+	key := message.User.ID + strconv.FormatInt(time.Now().UTC().UnixNano(), 10)
+	if err := a.saveSession(ctx, key, orderSession{ChannelID: message.Channel.ID, order: order{Shop: shop.ID}}); err != nil {
+		return err
+	}
+
+	// Create an order modal with the empty cart.
+	modal, err := a.newOrderModal(shop, key, nil, "")
+	if err != nil {
+		return err
+	}
+
+	// - metadata : ExternalID
+	modal.ExternalID = key
+
+	// Send the view to slack
+	if _, err := a.api.OpenViewContext(ctx, message.TriggerID, *modal); err != nil {
+		return fmt.Errorf("failed to open modal: %w", err)
+	}
+
+	return nil
+}
+
+// orderInputs are the inputs every order modal has, after the questions and the add-ons of the shop.
+type orderInputs struct {
+	Note string `input:"note" label:"Anything else you want to tell us?" optional:"true" multiline:"true" max_length:"500"`
+}
+
+var orderSchema = schema.Must(orderInputs{})
+
+// newOrderModal returns an order modal of shop with the cart c, which refers to the session key.
+// warning is shown under the cart unless it's empty.
+func (a *App) newOrderModal(shop *catalog.Shop, key string, c cart, warning string) (*slack.ModalViewRequest, error) {
+	// - apperance
+	// You can also create it by using a template, which is a JSON file. (see WithTemplates)
+	var modal *slack.ModalViewRequest
+	var err error
+	if a.templates != nil {
+		modal, err = createOrderModalByTemplate(a.templates, shop, c, warning)
+	} else {
+		modal, err = createOrderModalBySDK(shop, c, warning)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create modal: %w", err)
+	}
+
+	// - metadata : CallbackID
+	modal.CallbackID = reqOrderModalSubmission
+
+	// - metadata : PrivateMeta
+	bytes, err := json.Marshal(privateMeta{Session: key})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal private metadata: %w", err)
+	}
+	modal.PrivateMetadata = string(bytes)
+
+	return modal, nil
+}
+
+// createOrderModalBySDK makes a modal view by using slack-go/slack
+func createOrderModalBySDK(shop *catalog.Shop, c cart, warning string) (*slack.ModalViewRequest, error) {
+	// Text section
+	shopText := slack.NewTextBlockObject("mrkdwn", shop.Emoji+" *"+shop.Greeting+"*", false, false)
+	shopTextSection := slack.NewSectionBlock(shopText, nil, nil)
+
+	// Divider
+	dividerBlock := slack.NewDividerBlock()
+
+	// Text sections with an Add button
+	var menuSections []slack.Block
+	for _, item := range shop.Menu {
+		buttonText := slack.NewTextBlockObject("plain_text", "Add", false, false)
+		button := slack.NewButtonBlockElement(actionIDCartAdd, item.ID, buttonText)
+		itemText := slack.NewTextBlockObject("mrkdwn", "*"+item.Name+"*\n"+shop.Money(item.Price).String(), false, false)
+		menuSections = append(menuSections, slack.NewSectionBlock(itemText, nil, slack.NewAccessory(button), slack.SectionBlockOptionBlockID("block_id_menu_"+item.ID)))
+	}
+
+	// Text sections of the cart
+	cartBlocks := createCartBlocks(shop, c)
+
+	// Context with a warning
+	if warning != "" {
+		warningText := slack.NewTextBlockObject("mrkdwn", ":warning: "+warning, false, false)
+		cartBlocks = append(cartBlocks, slack.NewContextBlock("block_id_cart_warning", warningText))
+	}
+
+	// Inputs with static_select
+	questionInputs := createQuestionInputs(shop)
+
+	// Inputs with checkboxes or multi_static_select
+	addOnInputs := createAddOnInputs(shop)
+
+	// Inputs of orderInputs
+	fixedInputs, err := orderSchema.Blocks(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Blocks
+	// NOTE: Slack keeps what the user has entered in the inputs when the modal is updated,
+	// as long as their block IDs stay the same.
+	blockSet := []slack.Block{
+		shopTextSection,
+		dividerBlock,
+	}
+	blockSet = append(blockSet, menuSections...)
+	blockSet = append(blockSet, dividerBlock)
+	blockSet = append(blockSet, cartBlocks...)
+	blockSet = append(blockSet, dividerBlock)
+	blockSet = append(blockSet, questionInputs...)
+	blockSet = append(blockSet, addOnInputs...)
+	blockSet = append(blockSet, fixedInputs...)
+	blocks := slack.Blocks{BlockSet: blockSet}
+
+	// ModalView
+	modal := slack.ModalViewRequest{
+		Type:   slack.ViewType("modal"),
+		Title:  slack.NewTextBlockObject("plain_text", shop.Title, false, false),
+		Close:  slack.NewTextBlockObject("plain_text", "Cancel", false, false),
+		Submit: slack.NewTextBlockObject("plain_text", "Submit", false, false),
+		Blocks: blocks,
+	}
+
+	return &modal, nil
+}
@@ -0,0 +1,178 @@
+package interactiveapp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/nicoJN/slack-modal-examples/slackapp"
+	"github.com/nicoJN/slack-modal-examples/slackapp/catalog"
+	"github.com/nicoJN/slack-modal-examples/slackapp/logging"
+	"github.com/slack-go/slack"
+)
+
+// The order modal has an Add button for each menu item and a Remove button for each line of the cart.
+// Pushing one of them updates the modal with views.update, and the cart is kept in the session of the
+// order, which is overwritten on each update. The value of a button is the ID of the menu item.
+const (
+	actionIDCartPrefix = "action_id_cart_"
+	actionIDCartAdd    = actionIDCartPrefix + "add"
+	actionIDCartRemove = actionIDCartPrefix + "remove"
+)
+
+// maxQuantity is the largest quantity of a menu item in a cart.
+const maxQuantity = 10
+
+// cartLine is a menu item in a cart with its quantity.
+type cartLine struct {
+	Menu     string `json:"menu"`
+	Quantity int    `json:"quantity"`
+}
+
+// cart is the menu items of an order, in the order they were added.
+type cart []cartLine
+
+// add returns a copy of c with n more of menu, or fewer when n is negative.
+// A line is removed when its quantity drops to zero, and a quantity never exceeds maxQuantity.
+func (c cart) add(menu string, n int) cart {
+	var added cart
+	found := false
+	for _, line := range c {
+		if line.Menu == menu {
+			found = true
+			line.Quantity += n
+			if line.Quantity > maxQuantity {
+				line.Quantity = maxQuantity
+			}
+		}
+		if line.Quantity > 0 {
+			added = append(added, line)
+		}
+	}
+	if !found && n > 0 {
+		if n > maxQuantity {
+			n = maxQuantity
+		}
+		added = append(added, cartLine{Menu: menu, Quantity: n})
+	}
+	return added
+}
+
+// quantity returns the number of items in c.
+func (c cart) quantity() int {
+	n := 0
+	for _, line := range c {
+		n += line.Quantity
+	}
+	return n
+}
+
+func (a *App) handleCartActionRequest(ctx context.Context, message slack.InteractionCallback) (events.APIGatewayProxyResponse, error) {
+	if len(message.ActionCallback.BlockActions) == 0 {
+		return slackapp.OK(), fmt.Errorf("no actions in block_actions payload")
+	}
+
+	// Acknowledge the request and update the modal in the background.
+	return slackapp.OK(), a.enqueue(ctx, jobUpdateCart, message)
+}
+
+// runUpdateCart updates the order modal whose cart was edited.
+func (a *App) runUpdateCart(ctx context.Context, payload json.RawMessage) error {
+	var message slack.InteractionCallback
+	if err := json.Unmarshal(payload, &message); err != nil {
+		return fmt.Errorf("failed to unmarshal job payload: %w", err)
+	}
+	ctx = slackapp.TagInteraction(ctx, message)
+
+	if err := a.updateCart(ctx, message); err != nil {
+		// The request has already been acknowledged, so replace the modal with an error view.
+		a.reporter.ReportInteraction(ctx, message, err)
+	}
+	return nil
+}
+
+func (a *App) updateCart(ctx context.Context, message slack.InteractionCallback) error {
+	// Get the order which the private metadata of the modal refers to
+	key, s, err := a.loadSession(ctx, message.View)
+	if err != nil {
+		return err
+	}
+	shop, ok := a.catalog.Shop(s.Shop)
+	if !ok {
+		return fmt.Errorf("unknown shop: %s", s.Shop)
+	}
+
+	// Edit the cart.
+	for _, action := range message.ActionCallback.BlockActions {
+		if _, ok := shop.Item(action.Value); !ok {
+			return fmt.Errorf("unknown menu item of %s: %s", shop.ID, action.Value)
+		}
+
+		switch action.ActionID {
+		case actionIDCartAdd:
+			s.Cart = s.Cart.add(action.Value, 1)
+		case actionIDCartRemove:
+			s.Cart = s.Cart.add(action.Value, -1)
+		}
+	}
+
+	// Update the order modal.
+	modal, err := a.newOrderModal(shop, key, s.Cart, "")
+	if err != nil {
+		return err
+	}
+	modal.ExternalID = message.View.ExternalID
+
+	// The hash makes Slack reject the update when the modal has changed since the button was pushed.
+	if _, err := a.api.UpdateViewContext(ctx, *modal, "", message.View.Hash, message.View.ID); err != nil {
+		if err.Error() == "hash_conflict" {
+			// A newer update has won. The user sees its cart and can push the button again.
+			logging.FromContext(ctx).Info("Skipped an outdated cart update", logging.KeyError, err)
+			return nil
+		}
+		return fmt.Errorf("failed to update modal: %w", err)
+	}
+
+	// Save the cart only once the modal shows it, so that the cart of an outdated update isn't saved.
+	return a.saveSession(ctx, key, s)
+}
+
+// createCartBlocks returns a text section which heads the cart and a text section with a Remove button
+// for each line of the cart.
+func createCartBlocks(shop *catalog.Shop, c cart) []slack.Block {
+	headText := "*Your cart* :shopping_trolley:"
+	if len(c) == 0 {
+		headText += "\nNothing yet. Push *Add* to put an item in it."
+	}
+	blocks := []slack.Block{
+		slack.NewSectionBlock(slack.NewTextBlockObject("mrkdwn", headText, false, false), nil, nil),
+	}
+
+	for _, line := range c {
+		buttonText := slack.NewTextBlockObject("plain_text", "Remove", false, false)
+		button := slack.NewButtonBlockElement(actionIDCartRemove, line.Menu, buttonText)
+		text := slack.NewTextBlockObject("mrkdwn", cartLineName(shop, line), false, false)
+		blocks = append(blocks, slack.NewSectionBlock(text, nil, slack.NewAccessory(button), slack.SectionBlockOptionBlockID("block_id_cart_"+line.Menu)))
+	}
+	return blocks
+}
+
+// createCartText returns the lines of the cart, e.g. for the confirmation modal.
+func createCartText(shop *catalog.Shop, c cart) string {
+	var lines []string
+	for _, line := range c {
+		lines = append(lines, cartLineName(shop, line))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// cartLineName returns the name of the menu item with the quantity, e.g. "Cheese Burger × 2".
+func cartLineName(shop *catalog.Shop, line cartLine) string {
+	name := line.Menu
+	if item, ok := shop.Item(line.Menu); ok {
+		name = item.Name
+	}
+	return fmt.Sprintf("%s × %d", name, line.Quantity)
+}
@@ -0,0 +1,230 @@
+package interactiveapp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/nicoJN/slack-modal-examples/slackapp"
+	"github.com/nicoJN/slack-modal-examples/slackapp/catalog"
+	"github.com/nicoJN/slack-modal-examples/slackapp/logging"
+	"github.com/nicoJN/slack-modal-examples/slackapp/money"
+	"github.com/nicoJN/slack-modal-examples/slackapp/schema"
+	"github.com/nicoJN/slack-modal-examples/slackapp/validate"
+	"github.com/slack-go/slack"
+)
+
+func (a *App) handleConfirmationModalSubmissionRequest(ctx context.Context, message slack.InteractionCallback) (events.APIGatewayProxyResponse, error) {
+	// Get the order which the private metadata of the modal refers to
+	key, s, err := a.loadSession(ctx, message.View)
+	if err != nil {
+		return slackapp.OK(), err
+	}
+
+	// Get the chip. The inputs have been validated by chipForm.
+	chip, err := readChip(message.View.State, s.Amount)
+	if err != nil {
+		return slackapp.OK(), err
+	}
+
+	// Close the modal and send a complession message in the background.
+	return slackapp.OK(), a.enqueue(ctx, jobPostReceipt, receipt{Message: message, Session: key, Order: s, Chip: chip})
+}
+
+// receipt is the payload of a job which sends a complession message.
+type receipt struct {
+	Message slack.InteractionCallback `json:"message"`
+	Session string                    `json:"session"`
+	Order   orderSession              `json:"order"`
+	Chip    money.Money               `json:"chip"`
+}
+
+// runPostReceipt sends a complession message to the channel where the order started.
+func (a *App) runPostReceipt(ctx context.Context, payload json.RawMessage) error {
+	var r receipt
+	if err := json.Unmarshal(payload, &r); err != nil {
+		return fmt.Errorf("failed to unmarshal job payload: %w", err)
+	}
+	ctx = slackapp.TagInteraction(ctx, r.Message)
+
+	if err := a.postReceipt(ctx, r.Order, r.Chip); err != nil {
+		// The modal has already been closed, so tell the user with an ephemeral message.
+		a.reporter.Report(ctx, r.Order.ChannelID, r.Message.User.ID, err)
+		return nil
+	}
+
+	// The order is complete. A session left behind by a failure expires by itself.
+	if err := a.sessions.Delete(ctx, r.Session); err != nil {
+		logging.FromContext(ctx).Warn("Failed to delete the session of a complete order", logging.KeyError, err)
+	}
+	return nil
+}
+
+func (a *App) postReceipt(ctx context.Context, s orderSession, chip money.Money) error {
+	// Send a complession message.
+	// - Create message options
+	option, err := a.createOption(s, chip)
+	if err != nil {
+		return fmt.Errorf("failed to create message options: %w", err)
+	}
+
+	// - Post a message
+	if _, _, err := a.api.PostMessageContext(ctx, s.ChannelID, option); err != nil {
+		return fmt.Errorf("failed to send a message: %w", err)
+	}
+	return nil
+}
+
+// chipPresets are the percentages of the order offered as a chip.
+var chipPresets = []int64{10, 15, 20}
+
+// chipInputs are the inputs of a confirmation modal. Both are optional, and no chip is given when both
+// are left empty.
+type chipInputs struct {
+	Preset int64  `input:"chip_preset,static_select" label:"Chip" optional:"true"`
+	Custom string `input:"chip" label:"Or enter any amount" hint:"Thank you for your kindness!" optional:"true"`
+}
+
+var chipSchema = schema.Must(chipInputs{})
+
+// createChipInputs returns the inputs of chipInputs for an order of amount.
+// The presets show how much they are.
+func createChipInputs(amount money.Money) ([]slack.Block, error) {
+	var options []*slack.OptionBlockObject
+	for _, p := range chipPresets {
+		optText := slack.NewTextBlockObject("plain_text", fmt.Sprintf("%d%% (%s)", p, amount.Percent(p)), false, false)
+		options = append(options, slack.NewOptionBlockObject(strconv.FormatInt(p, 10), optText))
+	}
+	return chipSchema.Blocks(schema.Options{"chip_preset": options})
+}
+
+// confirmationForm returns the rules of the inputs of a confirmation modal.
+func (a *App) confirmationForm(ctx context.Context, message slack.InteractionCallback) (*validate.Form, error) {
+	_, s, err := a.loadSession(ctx, message.View)
+	if err != nil {
+		return nil, err
+	}
+	return chipForm(s.Amount), nil
+}
+
+// chipForm returns the rules of the chip inputs for an order of amount.
+// A custom chip must be a plain decimal number in the currency, from zero up to the amount of the order.
+func chipForm(amount money.Money) *validate.Form {
+	var presets []string
+	for _, p := range chipPresets {
+		presets = append(presets, strconv.FormatInt(p, 10))
+	}
+
+	digits := money.Digits(amount.Currency)
+	decimals := fmt.Sprintf("Use at most %d decimal places.", digits)
+	if digits == 0 {
+		decimals = "Enter a whole amount without decimals."
+	}
+
+	preset, custom := schema.BlockID("chip_preset"), schema.BlockID("chip")
+	return chipSchema.Form().
+		Field(preset, schema.ActionID("chip_preset"),
+			validate.OneOf(presets...).WithMessage("Choose one of the presets."),
+		).
+		Field(custom, schema.ActionID("chip"),
+			validate.Numeric().WithMessage(fmt.Sprintf("Enter an amount in digits, like %s.", money.New(150, amount.Currency).Decimal())),
+			validate.Decimals(digits).WithMessage(decimals),
+			validate.Min("0").WithMessage("A chip can't be negative."),
+			validate.Max(amount.Decimal()).WithMessage(fmt.Sprintf("A chip can't be more than the order (%s).", amount)),
+		).
+		CrossCheck(custom, func(v validate.Values) string {
+			if v.Get(preset, schema.ActionID("chip_preset")) != "" && v.Get(custom, schema.ActionID("chip")) != "" {
+				return "Choose a preset or enter an amount, not both."
+			}
+			return ""
+		})
+}
+
+// readChip returns the chip chosen or entered in the confirmation modal for an order of amount.
+func readChip(state *slack.ViewState, amount money.Money) (money.Money, error) {
+	var inputs chipInputs
+	if err := chipSchema.Decode(state, &inputs); err != nil {
+		return money.Money{}, err
+	}
+
+	if inputs.Preset != 0 {
+		return amount.Percent(inputs.Preset), nil
+	}
+	if inputs.Custom == "" {
+		return money.New(0, amount.Currency), nil
+	}
+
+	chip, err := money.Parse(inputs.Custom, amount.Currency)
+	if err != nil {
+		return money.Money{}, fmt.Errorf("invalid chip %q: %w", inputs.Custom, err)
+	}
+	return chip, nil
+}
+
+func (a *App) createOption(s orderSession, chip money.Money) (slack.MsgOption, error) {
+	// Look up the order in the catalog
+	shop, ok := a.catalog.Shop(s.Shop)
+	if !ok {
+		return nil, fmt.Errorf("unknown shop: %s", s.Shop)
+	}
+
+	items, total, err := priceOrder(shop, s.order)
+	if err != nil {
+		return nil, fmt.Errorf("failed to price the order: %w", err)
+	}
+
+	items = append(items, lineItem{Name: "Chip", Amount: chip})
+	total = total.Add(chip)
+
+	// You can also create it by using a template, which is a JSON file. (see WithTemplates)
+	if a.templates != nil {
+		blockSet, err := createReceiptByTemplate(a.templates, shop, s.order, items, total)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create a receipt: %w", err)
+		}
+		return slack.MsgOptionBlocks(blockSet...), nil
+	}
+	return slack.MsgOptionBlocks(createReceiptBySDK(shop, s.order, items, total)...), nil
+}
+
+// createReceiptBySDK makes the blocks of a receipt by using slack-go/slack
+func createReceiptBySDK(shop *catalog.Shop, o order, items []lineItem, total money.Money) []slack.Block {
+	// Text section
+	titleText := slack.NewTextBlockObject("mrkdwn", shop.Emoji+" *Thank you for your order !!*", false, false)
+	titleTextSection := slack.NewSectionBlock(titleText, nil, nil)
+
+	// Divider
+	dividerBlock := slack.NewDividerBlock()
+
+	// Text section
+	sMenuText := slack.NewTextBlockObject("mrkdwn", "*Menu*\n"+createCartText(shop, o.Cart), false, false)
+	sMenuTextSection := slack.NewSectionBlock(sMenuText, nil, nil)
+
+	// Text sections
+	answerSections := createAnswerSections(shop, o.Answers)
+	addOnSections := createAddOnSections(shop, o.AddOns)
+
+	// Text section
+	sNoteText := slack.NewTextBlockObject("mrkdwn", "*Anything else you want to tell us?*\n"+o.Note, false, false)
+	sNoteTextSection := slack.NewSectionBlock(sNoteText, nil, nil)
+
+	// Text section
+	amountTextSection := createBreakdownSection("*Total amount :moneybag:*", items, total)
+
+	// Blocks
+	blockSet := []slack.Block{
+		titleTextSection,
+		dividerBlock,
+		sMenuTextSection,
+	}
+	blockSet = append(blockSet, answerSections...)
+	blockSet = append(blockSet, addOnSections...)
+	blockSet = append(blockSet,
+		sNoteTextSection,
+		dividerBlock,
+		amountTextSection,
+	)
+	return blockSet
+}
@@ -0,0 +1,652 @@
+package interactiveapp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/nicoJN/slack-modal-examples/slackapp"
+	"github.com/nicoJN/slack-modal-examples/slackapp/catalog"
+	"github.com/nicoJN/slack-modal-examples/slackapp/config"
+	"github.com/nicoJN/slack-modal-examples/slackapp/money"
+	"github.com/nicoJN/slack-modal-examples/slackapp/slackapi/slackapitest"
+	"github.com/nicoJN/slack-modal-examples/slackapp/slacktest"
+	"github.com/nicoJN/slack-modal-examples/slackapp/worker"
+	"github.com/slack-go/slack"
+)
+
+func newTestApp(t *testing.T, opts ...Option) (*App, *slackapitest.Fake) {
+	t.Helper()
+
+	// The catalog shipped with the handlers.
+	cat, err := catalog.Load("../../catalog.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fake := slackapitest.NewFake()
+	cfg := &config.Config{SigningSecret: slacktest.SigningSecret, BotToken: "xoxb-test"}
+	return New(cfg, cat, append([]Option{WithSlackClient(fake)}, opts...)...), fake
+}
+
+// send signs an interaction payload and passes it to the App.
+func send(t *testing.T, a *App, message slack.InteractionCallback) events.APIGatewayProxyResponse {
+	t.Helper()
+
+	request, err := slacktest.NewInteractionRequest(slacktest.SigningSecret, message)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := a.HandleInteractiveRequest(context.Background(), request)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.StatusCode != 200 {
+		t.Fatalf("status code = %d, want 200", res.StatusCode)
+	}
+
+	// Wait for the slow work which runs after the ack.
+	if q, ok := a.queue.(*worker.InProcess); ok {
+		q.Wait()
+	}
+	return res
+}
+
+// openOrderModal pushes the hamburger button and returns the opened order modal.
+func openOrderModal(t *testing.T, a *App, fake *slackapitest.Fake) slack.ModalViewRequest {
+	t.Helper()
+
+	send(t, a, slacktest.ButtonPushed("U0001", "C0001", "actionIDHamburger", "hamburger"))
+
+	views := fake.OpenedViews()
+	if len(views) != 1 {
+		t.Fatalf("opened %d views, want 1", len(views))
+	}
+	return views[0].View
+}
+
+// addToCart pushes the Add buttons of menu items in the order modal and returns the updated modal.
+func addToCart(t *testing.T, a *App, fake *slackapitest.Fake, modal slack.ModalViewRequest, menus ...string) slack.ModalViewRequest {
+	t.Helper()
+
+	for _, menu := range menus {
+		send(t, a, slacktest.ViewButtonPushed("U0001", modal, actionIDCartAdd, menu))
+
+		views := fake.UpdatedViews()
+		if len(views) == 0 {
+			t.Fatalf("no views updated after adding %s", menu)
+		}
+		modal = views[len(views)-1].View
+	}
+	return modal
+}
+
+// submitOrder puts a cheese burger into the cart, submits the order modal and returns the confirmation modal.
+func submitOrder(t *testing.T, a *App, fake *slackapitest.Fake, modal slack.ModalViewRequest) slack.ModalViewRequest {
+	t.Helper()
+
+	modal = addToCart(t, a, fake, modal, "cheese_burger")
+	values := slacktest.Values(
+		slacktest.Selected("block_id_steak", "action_id_steak", "medium"),
+		slacktest.Text("block_id_note", "action_id_note", "No pickles, please."),
+	)
+	res := send(t, a, slacktest.ViewSubmission("U0001", modal, values))
+
+	r, err := slacktest.DecodeViewSubmissionResponse(res)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.ResponseAction != slack.RAUpdate || r.View == nil {
+		t.Fatalf("response action = %q, want update with a view", r.ResponseAction)
+	}
+	return *r.View
+}
+
+func TestOrderFlow(t *testing.T) {
+	a, fake := newTestApp(t)
+
+	// 2. Button pushed -> order modal
+	modal := openOrderModal(t, a, fake)
+	if modal.CallbackID != reqOrderModalSubmission {
+		t.Errorf("callback ID = %q, want %q", modal.CallbackID, reqOrderModalSubmission)
+	}
+	if got := fake.OpenedViews()[0].TriggerID; got != "trigger-actionIDHamburger" {
+		t.Errorf("trigger ID = %q, want trigger-actionIDHamburger", got)
+	}
+	if modal.Title.Text != "Hungryman Hamburgers" {
+		t.Errorf("title = %q, want Hungryman Hamburgers", modal.Title.Text)
+	}
+
+	// The private metadata only refers to the session of the order, which is keyed by the external ID.
+	var pMeta privateMeta
+	if err := json.Unmarshal([]byte(modal.PrivateMetadata), &pMeta); err != nil {
+		t.Fatal(err)
+	}
+	if pMeta.Session == "" || pMeta.Session != modal.ExternalID {
+		t.Errorf("private metadata = %s, want the session %s", modal.PrivateMetadata, modal.ExternalID)
+	}
+	_, s, err := a.loadSession(context.Background(), slack.View{PrivateMetadata: modal.PrivateMetadata})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.ChannelID != "C0001" {
+		t.Errorf("session channel = %q, want C0001", s.ChannelID)
+	}
+
+	// 3. Order modal submission -> confirmation modal
+	confirmation := submitOrder(t, a, fake, modal)
+	if confirmation.CallbackID != reqConfirmationModalSubmission {
+		t.Errorf("callback ID = %q, want %q", confirmation.CallbackID, reqConfirmationModalSubmission)
+	}
+
+	texts := strings.Join(slacktest.Texts(confirmation.Blocks), "\n")
+	for _, want := range []string{"Cheese Burger × 1", "medium", "No pickles, please.", "$ 700"} {
+		if !strings.Contains(texts, want) {
+			t.Errorf("confirmation modal doesn't contain %q:\n%s", want, texts)
+		}
+	}
+
+	_, s, err = a.loadSession(context.Background(), slack.View{PrivateMetadata: confirmation.PrivateMetadata})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := orderSession{
+		ChannelID: "C0001",
+		order:     order{Shop: "hamburger", Cart: cart{{Menu: "cheese_burger", Quantity: 1}}, Answers: map[string]string{"steak": "medium"}, Note: "No pickles, please.", Amount: money.New(70000, "USD")},
+	}
+	if !reflect.DeepEqual(s, want) {
+		t.Errorf("session = %+v, want %+v", s, want)
+	}
+
+	// 4. Confirmation modal submission -> receipt
+	res := send(t, a, slacktest.ViewSubmission("U0001", confirmation, slacktest.Text("block_id_chip", "action_id_chip", "100")))
+	if res.Body != "" {
+		t.Errorf("body = %q, want empty to close the modal", res.Body)
+	}
+
+	messages := fake.Messages()
+	if len(messages) != 1 {
+		t.Fatalf("posted %d messages, want 1", len(messages))
+	}
+	if messages[0].ChannelID != "C0001" {
+		t.Errorf("channel = %q, want C0001", messages[0].ChannelID)
+	}
+
+	texts = strings.Join(slacktest.Texts(messages[0].Blocks), "\n")
+	for _, want := range []string{"Thank you for your order", "Cheese Burger", "medium", "No pickles, please.", "$ 800.00"} {
+		if !strings.Contains(texts, want) {
+			t.Errorf("receipt doesn't contain %q:\n%s", want, texts)
+		}
+	}
+}
+
+func TestConfirmationValidationError(t *testing.T) {
+	tests := []struct {
+		name   string
+		values map[string]map[string]slack.BlockAction
+		block  string
+		want   string
+	}{
+		{"text", slacktest.Text("block_id_chip", "action_id_chip", "a lot"), "block_id_chip", "like 1.50"},
+		{"NaN", slacktest.Text("block_id_chip", "action_id_chip", "NaN"), "block_id_chip", "like 1.50"},
+		{"Inf", slacktest.Text("block_id_chip", "action_id_chip", "Inf"), "block_id_chip", "like 1.50"},
+		{"exponent", slacktest.Text("block_id_chip", "action_id_chip", "1e9"), "block_id_chip", "like 1.50"},
+		{"negative", slacktest.Text("block_id_chip", "action_id_chip", "-50"), "block_id_chip", "negative"},
+		{"decimals", slacktest.Text("block_id_chip", "action_id_chip", "1.505"), "block_id_chip", "2 decimal places"},
+		{"more than the order", slacktest.Text("block_id_chip", "action_id_chip", "700.01"), "block_id_chip", "$ 700.00"},
+		{"overflow", slacktest.Text("block_id_chip", "action_id_chip", "99999999999999999999"), "block_id_chip", "$ 700.00"},
+		{"preset and amount", slacktest.Values(
+			slacktest.Selected("block_id_chip_preset", "action_id_chip_preset", "15"),
+			slacktest.Text("block_id_chip", "action_id_chip", "100"),
+		), "block_id_chip", "not both"},
+		{"unknown preset", slacktest.Selected("block_id_chip_preset", "action_id_chip_preset", "50"), "block_id_chip_preset", "presets"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, fake := newTestApp(t)
+			confirmation := submitOrder(t, a, fake, openOrderModal(t, a, fake))
+
+			res := send(t, a, slacktest.ViewSubmission("U0001", confirmation, tt.values))
+
+			r, err := slacktest.DecodeViewSubmissionResponse(res)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if r.ResponseAction != slack.RAErrors {
+				t.Errorf("response action = %q, want errors", r.ResponseAction)
+			}
+			if msg := r.Errors[tt.block]; !strings.Contains(msg, tt.want) {
+				t.Errorf("errors = %v, want an error about %q for %s", r.Errors, tt.want, tt.block)
+			}
+			if n := len(fake.Messages()); n != 0 {
+				t.Errorf("posted %d messages, want 0", n)
+			}
+		})
+	}
+}
+
+func TestChip(t *testing.T) {
+	tests := []struct {
+		name   string
+		values map[string]map[string]slack.BlockAction
+		want   string
+	}{
+		{"empty", slacktest.Text("block_id_chip", "action_id_chip", ""), "Total: $ 700.00"},
+		{"no inputs", nil, "Total: $ 700.00"},
+		{"spaces", slacktest.Text("block_id_chip", "action_id_chip", " 1.5 "), "Total: $ 701.50"},
+		{"preset", slacktest.Selected("block_id_chip_preset", "action_id_chip_preset", "15"), "Total: $ 805.00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, fake := newTestApp(t)
+			confirmation := submitOrder(t, a, fake, openOrderModal(t, a, fake))
+
+			if res := send(t, a, slacktest.ViewSubmission("U0001", confirmation, tt.values)); res.Body != "" {
+				t.Fatalf("body = %q, want empty to close the modal", res.Body)
+			}
+			messages := fake.Messages()
+			if len(messages) != 1 {
+				t.Fatalf("posted %d messages, want 1", len(messages))
+			}
+			if texts := strings.Join(slacktest.Texts(messages[0].Blocks), "\n"); !strings.Contains(texts, tt.want) {
+				t.Errorf("receipt doesn't contain %q:\n%s", tt.want, texts)
+			}
+		})
+	}
+}
+
+func TestOtherShops(t *testing.T) {
+	tests := []struct {
+		shop     string
+		actionID string
+		title    string
+		menu     string
+		values   map[string]map[string]slack.BlockAction
+		want     []string
+		total    string
+	}{
+		{
+			shop:     "sushi",
+			actionID: "actionIDSushi",
+			title:    "Ace Wasabi Sushi Bar",
+			menu:     "chirashi",
+			values:   slacktest.Selected("block_id_wasabi", "action_id_wasabi", "less"),
+			want:     []string{"Chirashi Bowl", "How much wasabi?", "less", "Total: $ 1400.00"},
+			total:    "Total: $ 1400.00",
+		},
+		{
+			shop:     "ramen",
+			actionID: "actionIDRamen",
+			title:    "Sazanami Ramen",
+			menu:     "miso",
+			values: slacktest.Values(
+				slacktest.Selected("block_id_noodles", "action_id_noodles", "firm"),
+				slacktest.Selected("block_id_broth", "action_id_broth", "rich"),
+				slacktest.Checked("block_id_toppings", "action_id_toppings", "egg", "chashu"),
+			),
+			want:  []string{"Miso Ramen × 1: $ 900.00", "How rich is your broth? rich × 1: $ 100.00", "chashu, seasoned egg", "Toppings: chashu × 1: $ 200.00", "Total: $ 1300.00"},
+			total: "Total: $ 1300.00",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.shop, func(t *testing.T) {
+			a, fake := newTestApp(t)
+
+			send(t, a, slacktest.ButtonPushed("U0001", "C0001", tt.actionID, tt.shop))
+			views := fake.OpenedViews()
+			if len(views) != 1 {
+				t.Fatalf("opened %d views, want 1", len(views))
+			}
+			modal := views[0].View
+			if modal.Title.Text != tt.title {
+				t.Errorf("title = %q, want %q", modal.Title.Text, tt.title)
+			}
+			modal = addToCart(t, a, fake, modal, tt.menu)
+
+			r, err := slacktest.DecodeViewSubmissionResponse(send(t, a, slacktest.ViewSubmission("U0001", modal, tt.values)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if r.View == nil {
+				t.Fatalf("response = %+v, want a confirmation modal", r)
+			}
+			texts := strings.Join(slacktest.Texts(r.View.Blocks), "\n")
+			for _, want := range tt.want {
+				if !strings.Contains(texts, want) {
+					t.Errorf("confirmation modal doesn't contain %q:\n%s", want, texts)
+				}
+			}
+
+			send(t, a, slacktest.ViewSubmission("U0001", *r.View, slacktest.Text("block_id_chip", "action_id_chip", "0")))
+			messages := fake.Messages()
+			if len(messages) != 1 {
+				t.Fatalf("posted %d messages, want 1", len(messages))
+			}
+			if texts := strings.Join(slacktest.Texts(messages[0].Blocks), "\n"); !strings.Contains(texts, tt.total) {
+				t.Errorf("receipt doesn't contain %q:\n%s", tt.total, texts)
+			}
+		})
+	}
+}
+
+func TestCart(t *testing.T) {
+	a, fake := newTestApp(t)
+	modal := openOrderModal(t, a, fake)
+
+	// Two cheese burgers, and a hamburger which is removed again.
+	modal = addToCart(t, a, fake, modal, "cheese_burger", "hamburger", "cheese_burger")
+	send(t, a, slacktest.ViewButtonPushed("U0001", modal, actionIDCartRemove, "hamburger"))
+
+	views := fake.UpdatedViews()
+	if got := views[len(views)-1]; got.ViewID != "V0001" || got.Hash != "hash" {
+		t.Errorf("updated view %q with hash %q, want V0001 with hash", got.ViewID, got.Hash)
+	}
+	modal = views[len(views)-1].View
+	if modal.CallbackID != reqOrderModalSubmission {
+		t.Errorf("callback ID = %q, want %q", modal.CallbackID, reqOrderModalSubmission)
+	}
+
+	texts := strings.Join(slacktest.Texts(modal.Blocks), "\n")
+	if !strings.Contains(texts, "Cheese Burger × 2") || strings.Contains(texts, "Hamburger ×") {
+		t.Errorf("order modal doesn't show a cart with 2 cheese burgers:\n%s", texts)
+	}
+
+	// The answers apply to both burgers.
+	r, err := slacktest.DecodeViewSubmissionResponse(send(t, a, slacktest.ViewSubmission("U0001", modal, slacktest.Selected("block_id_steak", "action_id_steak", "rare"))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.View == nil {
+		t.Fatalf("response = %+v, want a confirmation modal", r)
+	}
+	texts = strings.Join(slacktest.Texts(r.View.Blocks), "\n")
+	for _, want := range []string{"Cheese Burger × 2: $ 1400.00", "How do you like your steak? rare × 2: $ 100.00", "Total: $ 1500.00"} {
+		if !strings.Contains(texts, want) {
+			t.Errorf("confirmation modal doesn't contain %q:\n%s", want, texts)
+		}
+	}
+
+	send(t, a, slacktest.ViewSubmission("U0001", *r.View, slacktest.Text("block_id_chip", "action_id_chip", "0")))
+	messages := fake.Messages()
+	if len(messages) != 1 {
+		t.Fatalf("posted %d messages, want 1", len(messages))
+	}
+	if texts := strings.Join(slacktest.Texts(messages[0].Blocks), "\n"); !strings.Contains(texts, "Cheese Burger × 2") {
+		t.Errorf("receipt doesn't contain the cart:\n%s", texts)
+	}
+}
+
+func TestAddOns(t *testing.T) {
+	a, fake := newTestApp(t)
+	modal := addToCart(t, a, fake, openOrderModal(t, a, fake), "cheese_burger", "hamburger")
+
+	values := slacktest.Values(
+		slacktest.Selected("block_id_steak", "action_id_steak", "medium"),
+		slacktest.Checked("block_id_toppings", "action_id_toppings", "no_onions", "extra_cheese"),
+	)
+	r, err := slacktest.DecodeViewSubmissionResponse(send(t, a, slacktest.ViewSubmission("U0001", modal, values)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.View == nil {
+		t.Fatalf("response = %+v, want a confirmation modal", r)
+	}
+
+	// Free add-ons are listed but not priced.
+	texts := strings.Join(slacktest.Texts(r.View.Blocks), "\n")
+	for _, want := range []string{"*Toppings*\nextra cheese, no onions", "Toppings: extra cheese × 2: $ 200.00", "Total: $ 1500.00"} {
+		if !strings.Contains(texts, want) {
+			t.Errorf("confirmation modal doesn't contain %q:\n%s", want, texts)
+		}
+	}
+	if strings.Contains(texts, "Toppings: no onions") {
+		t.Errorf("confirmation modal prices a free add-on:\n%s", texts)
+	}
+
+	send(t, a, slacktest.ViewSubmission("U0001", *r.View, slacktest.Text("block_id_chip", "action_id_chip", "0")))
+	messages := fake.Messages()
+	if len(messages) != 1 {
+		t.Fatalf("posted %d messages, want 1", len(messages))
+	}
+	if texts := strings.Join(slacktest.Texts(messages[0].Blocks), "\n"); !strings.Contains(texts, "extra cheese, no onions") {
+		t.Errorf("receipt doesn't contain the toppings:\n%s", texts)
+	}
+}
+
+func TestUnknownAddOn(t *testing.T) {
+	a, fake := newTestApp(t)
+	modal := addToCart(t, a, fake, openOrderModal(t, a, fake), "cheese_burger")
+
+	values := slacktest.Values(
+		slacktest.Selected("block_id_steak", "action_id_steak", "medium"),
+		slacktest.Checked("block_id_toppings", "action_id_toppings", "pineapple"),
+	)
+	r, err := slacktest.DecodeViewSubmissionResponse(send(t, a, slacktest.ViewSubmission("U0001", modal, values)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.View == nil || r.View.Title.Text != "Something went wrong" {
+		t.Fatalf("response = %+v, want an update to the error view", r)
+	}
+}
+
+func TestOrderValidationError(t *testing.T) {
+	a, fake := newTestApp(t)
+	modal := addToCart(t, a, fake, openOrderModal(t, a, fake), "cheese_burger")
+
+	// Both blocks are reported at once.
+	values := slacktest.Text("block_id_note", "action_id_note", strings.Repeat("a", 501))
+	r, err := slacktest.DecodeViewSubmissionResponse(send(t, a, slacktest.ViewSubmission("U0001", modal, values)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.ResponseAction != slack.RAErrors || r.Errors["block_id_steak"] == "" || r.Errors["block_id_note"] == "" {
+		t.Errorf("response = %+v, want errors for block_id_steak and block_id_note", r)
+	}
+}
+
+func TestEmptyCart(t *testing.T) {
+	a, fake := newTestApp(t)
+	modal := openOrderModal(t, a, fake)
+
+	r, err := slacktest.DecodeViewSubmissionResponse(send(t, a, slacktest.ViewSubmission("U0001", modal, slacktest.Selected("block_id_steak", "action_id_steak", "rare"))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.ResponseAction != slack.RAUpdate || r.View == nil || r.View.CallbackID != reqOrderModalSubmission {
+		t.Fatalf("response = %+v, want the order modal again", r)
+	}
+	if r.View.PrivateMetadata != modal.PrivateMetadata {
+		t.Errorf("private metadata = %s, want %s", r.View.PrivateMetadata, modal.PrivateMetadata)
+	}
+}
+
+// conflictOnce fails the first views.update like Slack does when the view has changed.
+type conflictOnce struct {
+	*slackapitest.Fake
+	failed bool
+}
+
+func (c *conflictOnce) UpdateViewContext(ctx context.Context, view slack.ModalViewRequest, externalID, hash, viewID string) (*slack.ViewResponse, error) {
+	if !c.failed {
+		c.failed = true
+		return nil, errors.New("hash_conflict")
+	}
+	return c.Fake.UpdateViewContext(ctx, view, externalID, hash, viewID)
+}
+
+func TestCartHashConflict(t *testing.T) {
+	fake := slackapitest.NewFake()
+	a, _ := newTestApp(t, WithSlackClient(&conflictOnce{Fake: fake}))
+	modal := openOrderModal(t, a, fake)
+
+	send(t, a, slacktest.ViewButtonPushed("U0001", modal, actionIDCartAdd, "cheese_burger"))
+
+	// An outdated update is dropped instead of replacing the modal with an error view.
+	if n := len(fake.UpdatedViews()); n != 0 {
+		t.Errorf("updated %d views, want 0", n)
+	}
+}
+
+func TestUnknownShop(t *testing.T) {
+	a, fake := newTestApp(t)
+
+	send(t, a, slacktest.ButtonPushed("U0001", "C0001", "actionIDSushi", "pizza"))
+
+	if n := len(fake.OpenedViews()); n != 0 {
+		t.Errorf("opened %d views, want 0", n)
+	}
+	if n := len(fake.Ephemerals()); n != 1 {
+		t.Errorf("posted %d ephemeral messages, want 1", n)
+	}
+}
+
+func TestUnknownInteraction(t *testing.T) {
+	a, fake := newTestApp(t)
+
+	var modal slack.ModalViewRequest
+	modal.CallbackID = "somethingElse"
+	send(t, a, slacktest.ViewSubmission("U0001", modal, nil))
+
+	if n := len(fake.Messages()) + len(fake.OpenedViews()); n != 0 {
+		t.Errorf("made %d calls, want 0", n)
+	}
+}
+
+func TestInvalidSignature(t *testing.T) {
+	a, fake := newTestApp(t)
+
+	request, err := slacktest.NewInteractionRequest("wrong-secret", slacktest.ButtonPushed("U0001", "C0001", "actionIDHamburger", "hamburger"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.HandleInteractiveRequest(context.Background(), request); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if n := len(fake.OpenedViews()); n != 0 {
+		t.Errorf("opened %d views, want 0", n)
+	}
+}
+
+func TestOpenViewFailure(t *testing.T) {
+	a, fake := newTestApp(t)
+	fake.OpenViewError = errors.New("expired_trigger_id")
+
+	res := send(t, a, slacktest.ButtonPushed("U0001", "C0001", "actionIDHamburger", "hamburger"))
+	if res.Body != "" {
+		t.Errorf("body = %q, want empty", res.Body)
+	}
+
+	ephemerals := fake.Ephemerals()
+	if len(ephemerals) != 1 {
+		t.Fatalf("posted %d ephemeral messages, want 1", len(ephemerals))
+	}
+	if e := ephemerals[0]; e.ChannelID != "C0001" || e.UserID != "U0001" || !strings.Contains(e.Text, "Reference") {
+		t.Errorf("ephemeral = %+v, want an error message to U0001 in C0001 with a reference", e)
+	}
+}
+
+func TestBrokenPrivateMetadata(t *testing.T) {
+	a, fake := newTestApp(t)
+	modal := openOrderModal(t, a, fake)
+	modal.PrivateMetadata = "{broken"
+
+	res := send(t, a, slacktest.ViewSubmission("U0001", modal, slacktest.Selected("block_id_steak", "action_id_steak", "rare")))
+
+	r, err := slacktest.DecodeViewSubmissionResponse(res)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.ResponseAction != slack.RAUpdate || r.View == nil || r.View.Title.Text != "Something went wrong" {
+		t.Fatalf("response = %+v, want an update to the error view", r)
+	}
+	if texts := strings.Join(slacktest.Texts(r.View.Blocks), "\n"); !strings.Contains(texts, "Reference") {
+		t.Errorf("error view %q has no reference", texts)
+	}
+}
+
+func TestButtonWithoutActions(t *testing.T) {
+	a, fake := newTestApp(t)
+
+	message := slacktest.ButtonPushed("U0001", "C0001", "actionIDHamburger", "hamburger")
+	a.router.Handle(slackapp.Route{Type: slack.InteractionTypeBlockActions}, a.handleButtonPushedRequest)
+	message.ActionCallback.BlockActions = nil
+
+	send(t, a, message)
+
+	if n := len(fake.OpenedViews()); n != 0 {
+		t.Errorf("opened %d views, want 0", n)
+	}
+}
+
+// queue holds jobs until they're run explicitly.
+type queue struct {
+	runner worker.Runner
+	jobs   []worker.Job
+}
+
+func (q *queue) Enqueue(ctx context.Context, job worker.Job) error {
+	q.jobs = append(q.jobs, job)
+	return nil
+}
+
+func TestAckBeforeSlowWork(t *testing.T) {
+	q := &queue{}
+	a, fake := newTestApp(t, WithQueue(func(r worker.Runner) worker.Queue {
+		q.runner = r
+		return q
+	}))
+
+	// The order modal is opened before the ack, since the trigger ID expires in 3 seconds.
+	send(t, a, slacktest.ButtonPushed("U0001", "C0001", "actionIDHamburger", "hamburger"))
+
+	views := fake.OpenedViews()
+	if len(views) != 1 {
+		t.Fatalf("opened %d views, want 1", len(views))
+	}
+	if len(q.jobs) != 0 {
+		t.Fatalf("jobs = %+v, want none", q.jobs)
+	}
+
+	// The cart is updated after the ack.
+	send(t, a, slacktest.ViewButtonPushed("U0001", views[0].View, actionIDCartAdd, "cheese_burger"))
+
+	if n := len(fake.UpdatedViews()); n != 0 {
+		t.Fatalf("updated %d views before the ack, want 0", n)
+	}
+	if len(q.jobs) != 1 || q.jobs[0].Kind != jobUpdateCart {
+		t.Fatalf("jobs = %+v, want a job to update the cart", q.jobs)
+	}
+
+	if err := q.runner.Run(context.Background(), q.jobs[0]); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(fake.UpdatedViews()); n != 1 {
+		t.Errorf("updated %d views, want 1", n)
+	}
+}
+
+func TestPostReceiptFailure(t *testing.T) {
+	a, fake := newTestApp(t)
+	confirmation := submitOrder(t, a, fake, openOrderModal(t, a, fake))
+	fake.PostMessageError = errors.New("channel_not_found")
+
+	res := send(t, a, slacktest.ViewSubmission("U0001", confirmation, slacktest.Text("block_id_chip", "action_id_chip", "100")))
+	if res.Body != "" {
+		t.Errorf("body = %q, want empty to close the modal", res.Body)
+	}
+
+	ephemerals := fake.Ephemerals()
+	if len(ephemerals) != 1 {
+		t.Fatalf("posted %d ephemeral messages, want 1", len(ephemerals))
+	}
+	if e := ephemerals[0]; e.ChannelID != "C0001" || e.UserID != "U0001" {
+		t.Errorf("ephemeral = %+v, want an error message to U0001 in C0001", e)
+	}
+}
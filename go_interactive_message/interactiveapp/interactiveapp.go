@@ -0,0 +1,191 @@
+// Package interactiveapp handles requests from Slack interactive components.
+// It sends an order modal, a confirmation modal and a completion message.
+package interactiveapp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/nicoJN/slack-modal-examples/slackapp"
+	"github.com/nicoJN/slack-modal-examples/slackapp/catalog"
+	"github.com/nicoJN/slack-modal-examples/slackapp/config"
+	"github.com/nicoJN/slack-modal-examples/slackapp/money"
+	"github.com/nicoJN/slack-modal-examples/slackapp/session"
+	"github.com/nicoJN/slack-modal-examples/slackapp/slackapi"
+	"github.com/nicoJN/slack-modal-examples/slackapp/templates"
+	"github.com/nicoJN/slack-modal-examples/slackapp/validate"
+	"github.com/nicoJN/slack-modal-examples/slackapp/worker"
+	"github.com/slack-go/slack"
+)
+
+var (
+	reqOrderModalSubmission        = "orderModalSubmission"
+	reqConfirmationModalSubmission = "confirmationModalSubmission"
+
+	// Kinds of the jobs which run after a request is acknowledged.
+	jobUpdateCart  = "updateCart"
+	jobPostReceipt = "postReceipt"
+)
+
+// App handles requests from Slack interactive components.
+type App struct {
+	api      slackapi.Client
+	catalog  *catalog.Catalog
+	router   *slackapp.Router
+	reporter *slackapp.ErrorReporter
+	handler  slackapp.Handler
+
+	// templates make the order modal and the receipt when they're set.
+	templates *templates.Set
+
+	// sessions keep the orders in progress.
+	sessions session.Store
+
+	jobs     *worker.Registry
+	queue    worker.Queue
+	newQueue func(worker.Runner) worker.Queue
+}
+
+// Option configures an App.
+type Option func(*App)
+
+// WithSlackClient replaces the Slack Web API client, e.g. with a fake in tests.
+func WithSlackClient(api slackapi.Client) Option {
+	return func(a *App) {
+		a.api = api
+	}
+}
+
+// WithQueue replaces the queue of the slow work, which runs in goroutines of this process by default.
+// newQueue receives the runner of the App's jobs, e.g. to build a worker.InProcess.
+// On Lambda, use a worker.LambdaQueue and start the function with worker.LambdaHandler.
+func WithQueue(newQueue func(worker.Runner) worker.Queue) Option {
+	return func(a *App) {
+		a.newQueue = newQueue
+	}
+}
+
+// New returns an App configured by cfg, which takes orders for the shops in cat.
+func New(cfg *config.Config, cat *catalog.Catalog, opts ...Option) *App {
+	a := &App{
+		api:     slackapi.New(cfg.BotToken),
+		catalog: cat,
+		router:  slackapp.NewRouter(),
+		jobs:    worker.NewRegistry(),
+		newQueue: func(r worker.Runner) worker.Queue {
+			return worker.NewInProcess(r, 4, 30*time.Second)
+		},
+		sessions: session.NewMemoryStore(),
+	}
+	if cfg.SessionFile != "" {
+		a.sessions = session.NewFileStore(cfg.SessionFile)
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	a.reporter = slackapp.NewErrorReporter(a.api)
+
+	// Slow work, which runs after the request is acknowledged.
+	a.jobs.Register(jobUpdateCart, a.runUpdateCart)
+	a.jobs.Register(jobPostReceipt, a.runPostReceipt)
+	a.queue = a.newQueue(a.jobs)
+
+	// Receive a button pushed message and send an order modal.
+	for _, shop := range a.catalog.Shops {
+		a.router.Handle(slackapp.Route{
+			Type:     slack.InteractionTypeBlockActions,
+			ActionID: slackapp.Exact(shop.ActionID),
+		}, a.handleButtonPushedRequest)
+	}
+
+	// Receive an Add or Remove button pushed message in an order modal and update the cart.
+	a.router.Handle(slackapp.Route{
+		Type:       slack.InteractionTypeBlockActions,
+		CallbackID: slackapp.Exact(reqOrderModalSubmission),
+		ActionID:   slackapp.Prefix(actionIDCartPrefix),
+	}, a.handleCartActionRequest)
+
+	// Receive an order modal submission message and send a confirmation modal.
+	// The inputs are validated first, and the errors are shown in the modal.
+	a.router.Handle(slackapp.Route{
+		Type:       slack.InteractionTypeViewSubmission,
+		CallbackID: slackapp.Exact(reqOrderModalSubmission),
+	}, validate.Submission(a.orderForm, a.handleOrderSubmissionRequest))
+
+	// Receive a confirmation modal submission message and send a complession message.
+	// The inputs are validated first, and the errors are shown in the modal.
+	a.router.Handle(slackapp.Route{
+		Type:       slack.InteractionTypeViewSubmission,
+		CallbackID: slackapp.Exact(reqConfirmationModalSubmission),
+	}, validate.Submission(a.confirmationForm, a.handleConfirmationModalSubmissionRequest))
+
+	a.handler = slackapp.Chain(a.handleInteractiveRequest, slackapp.Standard(cfg.SigningSecret)...)
+	return a
+}
+
+type order struct {
+	Shop    string              `json:"order_shop"`
+	Cart    cart                `json:"order_cart,omitempty"`
+	Answers map[string]string   `json:"order_answers,omitempty"` // Choice IDs keyed by question IDs.
+	AddOns  map[string][]string `json:"order_add_ons,omitempty"` // Option IDs keyed by add-on group IDs.
+	Note    string              `json:"order_note"`
+	Amount  money.Money         `json:"order_amount"`
+}
+
+// HandleInteractiveRequest handles a request from Slack interactive components
+// and dispatches it to the appropriate handler.
+// The request is verified by the middlewares before it's handled.
+func (a *App) HandleInteractiveRequest(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	return a.handler(ctx, request)
+}
+
+// Jobs returns the runner of the slow work, e.g. for worker.LambdaHandler.
+func (a *App) Jobs() worker.Runner {
+	return a.jobs
+}
+
+// Close waits for the slow work in progress, if the queue runs it in this process.
+func (a *App) Close() {
+	if q, ok := a.queue.(interface{ Close() }); ok {
+		q.Close()
+	}
+}
+
+// enqueue schedules a job with payload, so that the request can be acknowledged within 3 seconds.
+func (a *App) enqueue(ctx context.Context, kind string, payload interface{}) error {
+	job, err := worker.NewJob(ctx, kind, payload)
+	if err != nil {
+		return err
+	}
+	if err := a.queue.Enqueue(ctx, job); err != nil {
+		return fmt.Errorf("failed to enqueue %s: %w", kind, err)
+	}
+	return nil
+}
+
+func (a *App) handleInteractiveRequest(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	// Parse the request
+	payload, err := url.QueryUnescape(request.Body)
+	if err != nil {
+		return slackapp.AckError(ctx, "Failed to unescape", err)
+	}
+	payload = strings.Replace(payload, "payload=", "", 1)
+
+	var message slack.InteractionCallback
+	if err := json.Unmarshal([]byte(payload), &message); err != nil {
+		return slackapp.AckError(ctx, "Failed to unmarshal json", err)
+	}
+
+	// Dispatch message to appropreate handlers.
+	res, err := a.router.Dispatch(ctx, message)
+	if err != nil {
+		// Tell the user instead of silently closing the modal or ignoring the button.
+		return a.reporter.ReportInteraction(slackapp.TagInteraction(ctx, message), message, err)
+	}
+	return res, nil
+}
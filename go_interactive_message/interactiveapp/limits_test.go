@@ -0,0 +1,94 @@
+package interactiveapp
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/nicoJN/slack-modal-examples/slackapp/blockkit"
+	"github.com/nicoJN/slack-modal-examples/slackapp/catalog"
+	"github.com/nicoJN/slack-modal-examples/slackapp/money"
+)
+
+// largestOrder returns the largest order a user can make at shop: every menu item as many as possible,
+// the last choice of every question, every add-on and the longest note, made of characters which JSON
+// escapes.
+func largestOrder(shop *catalog.Shop) order {
+	o := order{Shop: shop.ID, Answers: map[string]string{}, AddOns: map[string][]string{}, Note: strings.Repeat(`"<`, 250)}
+	for _, item := range shop.Menu {
+		o.Cart = o.Cart.add(item.ID, maxQuantity)
+	}
+	for _, q := range shop.Questions {
+		o.Answers[q.ID] = q.Choices[len(q.Choices)-1].ID
+	}
+	for _, g := range shop.AddOns {
+		for _, opt := range g.Options {
+			o.AddOns[g.ID] = append(o.AddOns[g.ID], opt.ID)
+		}
+	}
+	return o
+}
+
+// Every modal and message stays within the limits of Block Kit, even for the largest order.
+func TestBuildersWithinLimits(t *testing.T) {
+	cat, err := catalog.Load("../../catalog.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	set, err := LoadTemplates(templateDir, cat)
+	if err != nil {
+		t.Fatal(err)
+	}
+	apps := map[string]*App{"sdk": {catalog: cat}, "template": {catalog: cat, templates: set}}
+
+	for i := range cat.Shops {
+		shop := &cat.Shops[i]
+		o := largestOrder(shop)
+		items, total, err := priceOrder(shop, o)
+		if err != nil {
+			t.Fatal(err)
+		}
+		o.Amount = total
+
+		for name, a := range apps {
+			t.Run(shop.ID+"/"+name, func(t *testing.T) {
+				// Order modal
+				for _, c := range []cart{nil, o.Cart} {
+					key := "U0001" + "1600000000000000000"
+					modal, err := a.newOrderModal(shop, key, c, "Add something to your cart before you submit.")
+					if err != nil {
+						t.Fatal(err)
+					}
+					modal.ExternalID = key
+					if err := blockkit.ValidateView(*modal); err != nil {
+						t.Errorf("order modal: %v", err)
+					}
+				}
+
+				// Confirmation modal
+				modal, err := createConfirmationModalBySDK(shop, o, items, total)
+				if err != nil {
+					t.Fatal(err)
+				}
+				b, err := json.Marshal(privateMeta{Session: "U0001" + "1600000000000000000"})
+				if err != nil {
+					t.Fatal(err)
+				}
+				modal.CallbackID = reqConfirmationModalSubmission
+				modal.PrivateMetadata = string(b)
+				if err := blockkit.ValidateView(*modal); err != nil {
+					t.Errorf("confirmation modal: %v", err)
+				}
+
+				// Receipt
+				option, err := a.createOption(orderSession{ChannelID: "C0001", order: o}, money.New(100000, shop.Currency))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := blockkit.ValidateMessage(option); err != nil {
+					t.Errorf("receipt: %v", err)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package interactiveapp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/nicoJN/slack-modal-examples/slackapp"
+	"github.com/nicoJN/slack-modal-examples/slackapp/blockkit"
+	"github.com/nicoJN/slack-modal-examples/slackapp/catalog"
+	"github.com/nicoJN/slack-modal-examples/slackapp/money"
+	"github.com/nicoJN/slack-modal-examples/slackapp/validate"
+	"github.com/slack-go/slack"
+)
+
+// orderForm returns the rules of the inputs of an order modal.
+func (a *App) orderForm(ctx context.Context, message slack.InteractionCallback) (*validate.Form, error) {
+	_, s, err := a.loadSession(ctx, message.View)
+	if err != nil {
+		return nil, err
+	}
+	shop, ok := a.catalog.Shop(s.Shop)
+	if !ok {
+		return nil, fmt.Errorf("unknown shop: %s", s.Shop)
+	}
+
+	// - inputs of orderInputs
+	form := orderSchema.Form()
+
+	// - static_select
+	for _, q := range shop.Questions {
+		var choices []string
+		for _, c := range q.Choices {
+			choices = append(choices, c.ID)
+		}
+		form.Field(questionBlockID(q), questionActionID(q), validate.Required(), validate.OneOf(choices...))
+	}
+
+	return form, nil
+}
+
+func (a *App) handleOrderSubmissionRequest(ctx context.Context, message slack.InteractionCallback) (events.APIGatewayProxyResponse, error) {
+	// Get the order which the private metadata of the modal refers to
+	key, s, err := a.loadSession(ctx, message.View)
+	if err != nil {
+		return slackapp.OK(), err
+	}
+	shop, ok := a.catalog.Shop(s.Shop)
+	if !ok {
+		return slackapp.OK(), fmt.Errorf("unknown shop: %s", s.Shop)
+	}
+
+	// Get the selected information.
+	// - cart, which has been kept in the session
+	if len(s.Cart) == 0 {
+		// Show the order modal again with a warning.
+		modal, err := a.newOrderModal(shop, key, s.Cart, "Add something to your cart before you submit.")
+		if err != nil {
+			return slackapp.OK(), err
+		}
+		modal.ExternalID = message.View.ExternalID
+
+		// A view in a response isn't checked by the client, so check the limits of Block Kit here.
+		if err := blockkit.ValidateView(*modal); err != nil {
+			return slackapp.OK(), fmt.Errorf("invalid order modal: %w", err)
+		}
+		return slackapp.JSON(slack.NewUpdateViewSubmissionResponse(modal))
+	}
+
+	// - static_select
+	answers, err := readAnswers(shop, message.View.State)
+	if err != nil {
+		return slackapp.OK(), err
+	}
+
+	// - checkboxes and multi_static_select
+	addOns, err := readAddOns(shop, message.View.State)
+	if err != nil {
+		return slackapp.OK(), err
+	}
+
+	// - inputs of orderInputs
+	var inputs orderInputs
+	if err := orderSchema.Decode(message.View.State, &inputs); err != nil {
+		return slackapp.OK(), err
+	}
+
+	o := order{
+		Shop:    shop.ID,
+		Cart:    s.Cart,
+		Answers: answers,
+		AddOns:  addOns,
+		Note:    inputs.Note,
+	}
+
+	// Calculate the amount.
+	items, total, err := priceOrder(shop, o)
+	if err != nil {
+		return slackapp.OK(), fmt.Errorf("failed to price the order: %w", err)
+	}
+	o.Amount = total
+
+	// Create a confirmation modal.
+	// - apperance
+	modal, err := createConfirmationModalBySDK(shop, o, items, total)
+	if err != nil {
+		return slackapp.OK(), fmt.Errorf("failed to create modal: %w", err)
+	}
+
+	// - metadata : CallbackID
+	modal.CallbackID = reqConfirmationModalSubmission
+
+	// - metadata : ExternalID
+	modal.ExternalID = message.User.ID + strconv.FormatInt(time.Now().UTC().UnixNano(), 10)
+
+	// - metadata : PrivateMeta, which refers to the session of the order
+	if err := a.saveSession(ctx, key, orderSession{ChannelID: s.ChannelID, order: o}); err != nil {
+		return slackapp.OK(), err
+	}
+
+	pBytes, err := json.Marshal(privateMeta{Session: key})
+	if err != nil {
+		return slackapp.OK(), fmt.Errorf("failed to marshal private metadata: %w", err)
+	}
+	modal.PrivateMetadata = string(pBytes)
+
+	// - limits of Block Kit
+	if err := blockkit.ValidateView(*modal); err != nil {
+		return slackapp.OK(), fmt.Errorf("invalid confirmation modal: %w", err)
+	}
+
+	// Create response
+	resAction := slack.NewUpdateViewSubmissionResponse(modal)
+	return slackapp.JSON(resAction)
+}
+
+func createConfirmationModalBySDK(shop *catalog.Shop, o order, items []lineItem, total money.Money) (*slack.ModalViewRequest, error) {
+
+	// Create a modal.
+	// - Text section
+	titleText := slack.NewTextBlockObject("mrkdwn", ":wave: *Order confirmation*", false, false)
+	titleTextSection := slack.NewSectionBlock(titleText, nil, nil)
+
+	// Divider
+	dividerBlock := slack.NewDividerBlock()
+
+	// - Text section
+	sMenuText := slack.NewTextBlockObject("mrkdwn", "*Menu "+shop.Emoji+"*\n"+createCartText(shop, o.Cart), false, false)
+	sMenuTextSection := slack.NewSectionBlock(sMenuText, nil, nil)
+
+	// - Text sections
+	answerSections := createAnswerSections(shop, o.Answers)
+	addOnSections := createAddOnSections(shop, o.AddOns)
+
+	// - Text section
+	sNoteText := slack.NewTextBlockObject("mrkdwn", "*Anything else you want to tell us?*\n"+o.Note, false, false)
+	sNoteTextSection := slack.NewSectionBlock(sNoteText, nil, nil)
+
+	// - Text section
+	amountTextSection := createBreakdownSection("*Amount :moneybag:*", items, total)
+
+	// - Inputs with static_select and plain_text_input
+	chipInputs, err := createChipInputs(total)
+	if err != nil {
+		return nil, err
+	}
+
+	// Blocks
+	blockSet := []slack.Block{
+		titleTextSection,
+		dividerBlock,
+		sMenuTextSection,
+	}
+	blockSet = append(blockSet, answerSections...)
+	blockSet = append(blockSet, addOnSections...)
+	blockSet = append(blockSet,
+		sNoteTextSection,
+		dividerBlock,
+		amountTextSection,
+	)
+	blockSet = append(blockSet, chipInputs...)
+	blocks := slack.Blocks{BlockSet: blockSet}
+
+	// ModalView
+	modal := slack.ModalViewRequest{
+		Type:   slack.ViewType("modal"),
+		Title:  slack.NewTextBlockObject("plain_text", shop.Title, false, false),
+		Close:  slack.NewTextBlockObject("plain_text", "Cancel", false, false),
+		Submit: slack.NewTextBlockObject("plain_text", "Order!", false, false),
+		Blocks: blocks,
+	}
+
+	return &modal, nil
+}
@@ -0,0 +1,74 @@
+package interactiveapp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nicoJN/slack-modal-examples/slackapp/catalog"
+	"github.com/nicoJN/slack-modal-examples/slackapp/money"
+	"github.com/slack-go/slack"
+)
+
+// lineItem is a row of the price breakdown of an order.
+type lineItem struct {
+	Name   string
+	Amount money.Money
+}
+
+// priceOrder returns the price breakdown of an order and its total.
+// The items in the cart come first, followed by the choices and the add-ons which cost extra.
+func priceOrder(shop *catalog.Shop, o order) ([]lineItem, money.Money, error) {
+	var items []lineItem
+	total := shop.Money(0)
+
+	for _, line := range o.Cart {
+		item, ok := shop.Item(line.Menu)
+		if !ok {
+			return nil, money.Money{}, fmt.Errorf("unknown menu item of %s: %s", shop.ID, line.Menu)
+		}
+
+		price := shop.Money(item.Price).Mul(int64(line.Quantity))
+		items = append(items, lineItem{Name: cartLineName(shop, line), Amount: price})
+		total = total.Add(price)
+	}
+
+	// The answers apply to every item in the cart, e.g. all the ramen get rich broth.
+	quantity := o.Cart.quantity()
+	for _, q := range shop.Questions {
+		c, ok := q.Choice(o.Answers[q.ID])
+		if !ok || c.Price == 0 {
+			continue
+		}
+
+		price := shop.Money(c.Price).Mul(int64(quantity))
+		items = append(items, lineItem{Name: fmt.Sprintf("%s %s × %d", q.Label, c.Name, quantity), Amount: price})
+		total = total.Add(price)
+	}
+
+	// So do the add-ons.
+	for _, g := range shop.AddOns {
+		for _, opt := range pickedOptions(g, o.AddOns) {
+			if opt.Price == 0 {
+				continue
+			}
+
+			price := shop.Money(opt.Price).Mul(int64(quantity))
+			items = append(items, lineItem{Name: fmt.Sprintf("%s: %s × %d", g.Label, opt.Name, quantity), Amount: price})
+			total = total.Add(price)
+		}
+	}
+
+	return items, total, nil
+}
+
+// createBreakdownSection returns a text section which lists the line items and the total.
+func createBreakdownSection(title string, items []lineItem, total money.Money) *slack.SectionBlock {
+	lines := []string{title}
+	for _, item := range items {
+		lines = append(lines, fmt.Sprintf("%s: %s", item.Name, item.Amount))
+	}
+	lines = append(lines, fmt.Sprintf("*Total: %s*", total))
+
+	text := slack.NewTextBlockObject("mrkdwn", strings.Join(lines, "\n"), false, false)
+	return slack.NewSectionBlock(text, nil, nil)
+}
@@ -0,0 +1,73 @@
+package interactiveapp
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nicoJN/slack-modal-examples/slackapp/catalog"
+	"github.com/nicoJN/slack-modal-examples/slackapp/money"
+)
+
+func TestPriceOrder(t *testing.T) {
+	shop := &catalog.Shop{
+		ID:       "hamburger",
+		Currency: "USD",
+		Menu:     []catalog.Item{{ID: "cheese_burger", Name: "Cheese Burger", Price: 10}},
+		Questions: []catalog.Question{
+			{ID: "steak", Label: "Steak", Choices: []catalog.Choice{{ID: "medium", Name: "medium"}, {ID: "blue", Name: "blue", Price: 20}}},
+			{ID: "size", Label: "Size", Choices: []catalog.Choice{{ID: "regular", Name: "regular", Price: 0}}},
+		},
+		AddOns: []catalog.AddOnGroup{
+			{ID: "toppings", Label: "Toppings", Options: []catalog.Choice{{ID: "bacon", Name: "bacon", Price: 5}, {ID: "no_onions", Name: "no onions"}}},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		answers   map[string]string
+		addOns    map[string][]string
+		wantItems []lineItem
+		wantTotal money.Money
+	}{
+		{"free choices", map[string]string{"steak": "medium", "size": "regular"}, nil, []lineItem{{"Cheese Burger × 3", money.New(30, "USD")}}, money.New(30, "USD")},
+		// 0.30 + 0.60 is exactly 0.90, unlike with float64.
+		{"surcharge", map[string]string{"steak": "blue", "size": "regular"}, nil, []lineItem{{"Cheese Burger × 3", money.New(30, "USD")}, {"Steak blue × 3", money.New(60, "USD")}}, money.New(90, "USD")},
+		{"add-ons", map[string]string{"steak": "medium"}, map[string][]string{"toppings": {"no_onions", "bacon"}}, []lineItem{{"Cheese Burger × 3", money.New(30, "USD")}, {"Toppings: bacon × 3", money.New(15, "USD")}}, money.New(45, "USD")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, total, err := priceOrder(shop, order{Cart: cart{{Menu: "cheese_burger", Quantity: 3}}, Answers: tt.answers, AddOns: tt.addOns})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(items, tt.wantItems) || total != tt.wantTotal {
+				t.Errorf("got (%v, %v), want (%v, %v)", items, total, tt.wantItems, tt.wantTotal)
+			}
+		})
+	}
+
+	if _, _, err := priceOrder(shop, order{Cart: cart{{Menu: "pizza", Quantity: 1}}}); err == nil {
+		t.Error("expected an error for an unknown item")
+	}
+}
+
+func TestCartAdd(t *testing.T) {
+	var c cart
+	c = c.add("hamburger", 1)
+	c = c.add("cheese_burger", 2)
+	c = c.add("hamburger", 1)
+	want := cart{{Menu: "hamburger", Quantity: 2}, {Menu: "cheese_burger", Quantity: 2}}
+	if !reflect.DeepEqual(c, want) {
+		t.Errorf("cart = %+v, want %+v", c, want)
+	}
+
+	c = c.add("hamburger", -2).add("cheese_burger", maxQuantity)
+	want = cart{{Menu: "cheese_burger", Quantity: maxQuantity}}
+	if !reflect.DeepEqual(c, want) || c.quantity() != maxQuantity {
+		t.Errorf("cart = %+v, want %+v", c, want)
+	}
+
+	if c := c.add("pizza", -1); !reflect.DeepEqual(c, want) {
+		t.Errorf("removing an item not in the cart changed it to %+v", c)
+	}
+}
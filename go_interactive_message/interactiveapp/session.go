@@ -0,0 +1,101 @@
+package interactiveapp
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/nicoJN/slack-modal-examples/slackapp/config"
+	"github.com/nicoJN/slack-modal-examples/slackapp/session"
+	"github.com/slack-go/slack"
+)
+
+// An order in progress is kept in a session store, and the private metadata of the order modal and the
+// confirmation modal only refers to it, since a long note or a large cart doesn't fit in the 3000
+// characters of private_metadata. The session is keyed by the external ID of the order modal, which is
+// unique to the user and the time the modal was opened.
+
+// sessionTTL is how long an order can stay in progress.
+const sessionTTL = 24 * time.Hour
+
+// privateMeta is kept in the private metadata of the modals.
+type privateMeta struct {
+	Session string `json:"session"`
+}
+
+// orderSession is the state of an order in progress.
+type orderSession struct {
+	ChannelID string `json:"channel_id"`
+	order
+}
+
+// WithSessionStore replaces the store of the orders in progress.
+// They're kept in memory by default, or in SESSION_FILE when it's set.
+func WithSessionStore(store session.Store) Option {
+	return func(a *App) {
+		a.sessions = store
+	}
+}
+
+// sqliteDriver is the name of the SQLite driver of database/sql, which main registers with a blank
+// import of modernc.org/sqlite.
+const sqliteDriver = "sqlite"
+
+// NewSessionStore returns the store of the orders in progress which cfg sets: the DynamoDB table of
+// SESSION_TABLE, the SQLite database of SESSION_DB, the file of SESSION_FILE, or memory.
+// Only the first two are shared between Lambda containers.
+func NewSessionStore(ctx context.Context, cfg *config.Config) (session.Store, error) {
+	switch {
+	case cfg.SessionTable != "":
+		return session.NewDynamoDBStoreFromEnv(cfg.SessionTable)
+	case cfg.SessionDB != "":
+		db, err := sql.Open(sqliteDriver, cfg.SessionDB)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open the session database: %w", err)
+		}
+		s := session.NewSQLiteStore(db)
+		if err := s.CreateTable(ctx); err != nil {
+			db.Close()
+			return nil, err
+		}
+		return s, nil
+	case cfg.SessionFile != "":
+		return session.NewFileStore(cfg.SessionFile), nil
+	}
+	return session.NewMemoryStore(), nil
+}
+
+// saveSession keeps s as the session key.
+func (a *App) saveSession(ctx context.Context, key string, s orderSession) error {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the order: %w", err)
+	}
+	if err := a.sessions.Save(ctx, key, b, sessionTTL); err != nil {
+		return fmt.Errorf("failed to save the order: %w", err)
+	}
+	return nil
+}
+
+// loadSession returns the key and the state of the session which the private metadata of view refers to.
+func (a *App) loadSession(ctx context.Context, view slack.View) (string, orderSession, error) {
+	var pMeta privateMeta
+	if err := json.Unmarshal([]byte(view.PrivateMetadata), &pMeta); err != nil {
+		return "", orderSession{}, fmt.Errorf("failed to unmarshal private metadata: %w", err)
+	}
+	if pMeta.Session == "" {
+		return "", orderSession{}, fmt.Errorf("no session in private metadata")
+	}
+
+	b, err := a.sessions.Load(ctx, pMeta.Session)
+	if err != nil {
+		return "", orderSession{}, fmt.Errorf("failed to load the order: %w", err)
+	}
+	var s orderSession
+	if err := json.Unmarshal(b, &s); err != nil {
+		return "", orderSession{}, fmt.Errorf("failed to unmarshal the order: %w", err)
+	}
+	return pMeta.Session, s, nil
+}
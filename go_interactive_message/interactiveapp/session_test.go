@@ -0,0 +1,183 @@
+package interactiveapp
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nicoJN/slack-modal-examples/slackapp/blockkit"
+	"github.com/nicoJN/slack-modal-examples/slackapp/config"
+	"github.com/nicoJN/slack-modal-examples/slackapp/session"
+	"github.com/nicoJN/slack-modal-examples/slackapp/slackapi/slackapitest"
+	"github.com/nicoJN/slack-modal-examples/slackapp/slacktest"
+	"github.com/slack-go/slack"
+	_ "modernc.org/sqlite"
+)
+
+// A long note of characters which JSON escapes used to overflow the private metadata of the confirmation
+// modal. Only the session is referred to now.
+func TestLongNote(t *testing.T) {
+	a, fake := newTestApp(t)
+	modal := addToCart(t, a, fake, openOrderModal(t, a, fake), "cheese_burger")
+
+	note := strings.Repeat(`"<`, 250)
+	values := slacktest.Values(
+		slacktest.Selected("block_id_steak", "action_id_steak", "medium"),
+		slacktest.Text("block_id_note", "action_id_note", note),
+	)
+	r, err := slacktest.DecodeViewSubmissionResponse(send(t, a, slacktest.ViewSubmission("U0001", modal, values)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.View == nil || r.View.CallbackID != reqConfirmationModalSubmission {
+		t.Fatalf("response = %+v, want a confirmation modal", r)
+	}
+	if err := blockkit.ValidateView(*r.View); err != nil {
+		t.Errorf("confirmation modal: %v", err)
+	}
+
+	send(t, a, slacktest.ViewSubmission("U0001", *r.View, slacktest.Text("block_id_chip", "action_id_chip", "0")))
+	messages := fake.Messages()
+	if len(messages) != 1 {
+		t.Fatalf("posted %d messages, want 1", len(messages))
+	}
+	if texts := strings.Join(slacktest.Texts(messages[0].Blocks), "\n"); !strings.Contains(texts, note) {
+		t.Errorf("receipt doesn't contain the note:\n%s", texts)
+	}
+}
+
+func TestCartUpdatesOverwriteSession(t *testing.T) {
+	a, fake := newTestApp(t)
+	modal := openOrderModal(t, a, fake)
+	for _, menu := range []string{"cheese_burger", "cheese_burger"} {
+		modal = addToCart(t, a, fake, modal, menu)
+	}
+
+	key, s, err := a.loadSession(context.Background(), slack.View{PrivateMetadata: modal.PrivateMetadata})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key != modal.ExternalID {
+		t.Errorf("session = %q, want the external ID %q", key, modal.ExternalID)
+	}
+	if len(s.Cart) != 1 || s.Cart[0].Quantity != 2 {
+		t.Errorf("cart = %+v, want 2 cheese burgers", s.Cart)
+	}
+}
+
+func TestSessionDeletedAfterReceipt(t *testing.T) {
+	a, fake := newTestApp(t)
+	confirmation := submitOrder(t, a, fake, openOrderModal(t, a, fake))
+
+	send(t, a, slacktest.ViewSubmission("U0001", confirmation, slacktest.Text("block_id_chip", "action_id_chip", "0")))
+	if n := len(fake.Messages()); n != 1 {
+		t.Fatalf("posted %d messages, want 1", n)
+	}
+	if _, _, err := a.loadSession(context.Background(), slack.View{PrivateMetadata: confirmation.PrivateMetadata}); err == nil {
+		t.Error("the session is left after the receipt is posted")
+	}
+}
+
+func TestExpiredSession(t *testing.T) {
+	tests := []struct {
+		name  string
+		modal func(t *testing.T, a *App, fake *slackapitest.Fake) slack.ModalViewRequest
+		value map[string]map[string]slack.BlockAction
+	}{
+		{
+			name: "order modal",
+			modal: func(t *testing.T, a *App, fake *slackapitest.Fake) slack.ModalViewRequest {
+				return addToCart(t, a, fake, openOrderModal(t, a, fake), "cheese_burger")
+			},
+			value: slacktest.Selected("block_id_steak", "action_id_steak", "rare"),
+		},
+		{
+			name: "confirmation modal",
+			modal: func(t *testing.T, a *App, fake *slackapitest.Fake) slack.ModalViewRequest {
+				return submitOrder(t, a, fake, openOrderModal(t, a, fake))
+			},
+			value: slacktest.Text("block_id_chip", "action_id_chip", "0"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, fake := newTestApp(t)
+			modal := tt.modal(t, a, fake)
+
+			// The session expired, or was saved by another process.
+			key, _, err := a.loadSession(context.Background(), slack.View{PrivateMetadata: modal.PrivateMetadata})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := a.sessions.Delete(context.Background(), key); err != nil {
+				t.Fatal(err)
+			}
+
+			r, err := slacktest.DecodeViewSubmissionResponse(send(t, a, slacktest.ViewSubmission("U0001", modal, tt.value)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if r.ResponseAction != slack.RAUpdate || r.View == nil || r.View.Title.Text != "Something went wrong" {
+				t.Fatalf("response = %+v, want an update to the error view", r)
+			}
+			if n := len(fake.Messages()); n != 0 {
+				t.Errorf("posted %d messages, want 0", n)
+			}
+		})
+	}
+}
+
+func TestSessionStores(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  func(dir string) *config.Config
+	}{
+		// Another process on the same file, e.g. after a restart, carries on with the order.
+		{name: "file", cfg: func(dir string) *config.Config {
+			return &config.Config{SessionFile: filepath.Join(dir, "sessions.json")}
+		}},
+		// Another process on the same database, e.g. another container on a shared file system, carries
+		// on with the order.
+		{name: "sqlite", cfg: func(dir string) *config.Config {
+			return &config.Config{SessionDB: filepath.Join(dir, "sessions.db")}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "interactiveapp")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+			cfg := tt.cfg(dir)
+
+			a, fake := newTestApp(t, WithSessionStore(newSessionStore(t, cfg)))
+			modal := addToCart(t, a, fake, openOrderModal(t, a, fake), "cheese_burger")
+
+			b, _ := newTestApp(t, WithSessionStore(newSessionStore(t, cfg)), WithSlackClient(fake))
+			r, err := slacktest.DecodeViewSubmissionResponse(send(t, b, slacktest.ViewSubmission("U0001", modal, slacktest.Selected("block_id_steak", "action_id_steak", "rare"))))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if r.View == nil || r.View.CallbackID != reqConfirmationModalSubmission {
+				t.Fatalf("response = %+v, want a confirmation modal", r)
+			}
+			if texts := strings.Join(slacktest.Texts(r.View.Blocks), "\n"); !strings.Contains(texts, "Cheese Burger × 1") {
+				t.Errorf("confirmation modal doesn't contain the cart:\n%s", texts)
+			}
+		})
+	}
+}
+
+func newSessionStore(t *testing.T, cfg *config.Config) session.Store {
+	t.Helper()
+
+	s, err := NewSessionStore(context.Background(), cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
@@ -0,0 +1,166 @@
+package interactiveapp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nicoJN/slack-modal-examples/slackapp/catalog"
+	"github.com/nicoJN/slack-modal-examples/slackapp/schema"
+	"github.com/slack-go/slack"
+)
+
+// Every shop takes orders with the same flow, built from its entry in the catalog.
+// The questions of a shop (e.g. how you like your steak) are asked with static selects,
+// and its add-ons (e.g. toppings) with checkboxes or multi selects.
+// Their block IDs and action IDs are derived from the question IDs and the add-on group IDs
+// in the same way as the inputs of a schema, which are fixed for every shop.
+
+func questionBlockID(q catalog.Question) string {
+	return schema.BlockID(q.ID)
+}
+
+func questionActionID(q catalog.Question) string {
+	return schema.ActionID(q.ID)
+}
+
+func addOnBlockID(g catalog.AddOnGroup) string {
+	return schema.BlockID(g.ID)
+}
+
+func addOnActionID(g catalog.AddOnGroup) string {
+	return schema.ActionID(g.ID)
+}
+
+// createQuestionInputs returns an input with static_select for each question of a shop.
+func createQuestionInputs(shop *catalog.Shop) []slack.Block {
+	var blocks []slack.Block
+	for _, q := range shop.Questions {
+		var options []*slack.OptionBlockObject
+		for _, c := range q.Choices {
+			optText := slack.NewTextBlockObject("plain_text", c.Name, false, false)
+			options = append(options, slack.NewOptionBlockObject(c.ID, optText))
+		}
+
+		element := slack.NewOptionsSelectBlockElement("static_select", nil, questionActionID(q), options...)
+		label := slack.NewTextBlockObject("plain_text", q.Label, false, false)
+		blocks = append(blocks, slack.NewInputBlock(questionBlockID(q), label, element))
+	}
+	return blocks
+}
+
+// readAnswers returns the chosen choice IDs keyed by question IDs.
+func readAnswers(shop *catalog.Shop, state *slack.ViewState) (map[string]string, error) {
+	if state == nil {
+		state = &slack.ViewState{}
+	}
+
+	answers := map[string]string{}
+	for _, q := range shop.Questions {
+		id := state.Values[questionBlockID(q)][questionActionID(q)].SelectedOption.Value
+		if _, ok := q.Choice(id); !ok {
+			return nil, fmt.Errorf("unknown choice of %s: %q", q.ID, id)
+		}
+		answers[q.ID] = id
+	}
+	return answers, nil
+}
+
+// createAnswerSections returns a text section for each answer, in the order of the questions.
+func createAnswerSections(shop *catalog.Shop, answers map[string]string) []slack.Block {
+	var blocks []slack.Block
+	for _, q := range shop.Questions {
+		name := answers[q.ID]
+		if c, ok := q.Choice(name); ok {
+			name = c.Name
+		}
+
+		text := slack.NewTextBlockObject("mrkdwn", "*"+q.Label+"*\n"+name, false, false)
+		blocks = append(blocks, slack.NewSectionBlock(text, nil, nil))
+	}
+	return blocks
+}
+
+// createAddOnInputs returns an optional input with checkboxes or multi_static_select for each add-on group
+// of a shop. The options show their prices.
+func createAddOnInputs(shop *catalog.Shop) []slack.Block {
+	var blocks []slack.Block
+	for _, g := range shop.AddOns {
+		var options []*slack.OptionBlockObject
+		for _, o := range g.Options {
+			optText := slack.NewTextBlockObject("plain_text", addOnOptionName(shop, o), false, false)
+			options = append(options, slack.NewOptionBlockObject(o.ID, optText))
+		}
+
+		var element slack.BlockElement
+		if g.Input == catalog.InputMultiSelect {
+			placeholder := slack.NewTextBlockObject("plain_text", "Select ...", false, false)
+			element = slack.NewOptionsMultiSelectBlockElement(slack.MultiOptTypeStatic, placeholder, addOnActionID(g), options...)
+		} else {
+			element = slack.NewCheckboxGroupsBlockElement(addOnActionID(g), options...)
+		}
+
+		label := slack.NewTextBlockObject("plain_text", g.Label, false, false)
+		input := slack.NewInputBlock(addOnBlockID(g), label, element)
+		input.Optional = true
+		blocks = append(blocks, input)
+	}
+	return blocks
+}
+
+// addOnOptionName returns the name of an add-on option with its price, e.g. "bacon (+$ 1.50)".
+func addOnOptionName(shop *catalog.Shop, o catalog.Choice) string {
+	if o.Price == 0 {
+		return o.Name
+	}
+	return o.Name + " (+" + shop.Money(o.Price).String() + ")"
+}
+
+// readAddOns returns the picked option IDs keyed by add-on group IDs. Groups without picks are left out.
+func readAddOns(shop *catalog.Shop, state *slack.ViewState) (map[string][]string, error) {
+	if state == nil {
+		state = &slack.ViewState{}
+	}
+
+	addOns := map[string][]string{}
+	for _, g := range shop.AddOns {
+		for _, selected := range state.Values[addOnBlockID(g)][addOnActionID(g)].SelectedOptions {
+			if _, ok := g.Option(selected.Value); !ok {
+				return nil, fmt.Errorf("unknown option of %s: %q", g.ID, selected.Value)
+			}
+			addOns[g.ID] = append(addOns[g.ID], selected.Value)
+		}
+	}
+	return addOns, nil
+}
+
+// pickedOptions returns the options of g picked in addOns, in the order of the catalog.
+func pickedOptions(g catalog.AddOnGroup, addOns map[string][]string) []catalog.Choice {
+	var picked []catalog.Choice
+	for _, o := range g.Options {
+		for _, id := range addOns[g.ID] {
+			if id == o.ID {
+				picked = append(picked, o)
+				break
+			}
+		}
+	}
+	return picked
+}
+
+// createAddOnSections returns a text section for each add-on group, in the order of the catalog.
+func createAddOnSections(shop *catalog.Shop, addOns map[string][]string) []slack.Block {
+	var blocks []slack.Block
+	for _, g := range shop.AddOns {
+		var names []string
+		for _, o := range pickedOptions(g, addOns) {
+			names = append(names, o.Name)
+		}
+		if len(names) == 0 {
+			names = []string{"none"}
+		}
+
+		text := slack.NewTextBlockObject("mrkdwn", "*"+g.Label+"*\n"+strings.Join(names, ", "), false, false)
+		blocks = append(blocks, slack.NewSectionBlock(text, nil, nil))
+	}
+	return blocks
+}
@@ -0,0 +1,182 @@
+package interactiveapp
+
+import (
+	"fmt"
+
+	"github.com/nicoJN/slack-modal-examples/slackapp/catalog"
+	"github.com/nicoJN/slack-modal-examples/slackapp/money"
+	"github.com/nicoJN/slack-modal-examples/slackapp/templates"
+	"github.com/slack-go/slack"
+)
+
+// The order modal and the receipt can also be made from templates, which are loaded from TEMPLATE_DIR.
+// The templates get the data below, in which everything is already looked up in the catalog and
+// formatted, so that they only decide the appearance.
+
+// Names of the templates.
+const (
+	tmplOrderModal = "order_modal"
+	tmplReceipt    = "receipt"
+)
+
+// WithTemplates makes the order modal and the receipt from the templates in set instead of in Go.
+// Load them with LoadTemplates.
+func WithTemplates(set *templates.Set) Option {
+	return func(a *App) {
+		a.templates = set
+	}
+}
+
+// LoadTemplates loads the templates in dir and checks that every shop in cat can be rendered with them,
+// so that a broken template is found at startup instead of when a user orders.
+func LoadTemplates(dir string, cat *catalog.Catalog) (*templates.Set, error) {
+	set, err := templates.Load(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range cat.Shops {
+		shop := &cat.Shops[i]
+
+		// An order with the first choice of everything
+		o := order{Shop: shop.ID, Answers: map[string]string{}, AddOns: map[string][]string{}, Note: "Thank you."}
+		if len(shop.Menu) > 0 {
+			o.Cart = o.Cart.add(shop.Menu[0].ID, 1)
+		}
+		for _, q := range shop.Questions {
+			o.Answers[q.ID] = q.Choices[0].ID
+		}
+		for _, g := range shop.AddOns {
+			o.AddOns[g.ID] = []string{g.Options[0].ID}
+		}
+
+		if _, err := createOrderModalByTemplate(set, shop, o.Cart, "Check your order."); err != nil {
+			return nil, fmt.Errorf("shop %s: %w", shop.ID, err)
+		}
+		items, total, err := priceOrder(shop, o)
+		if err != nil {
+			return nil, fmt.Errorf("shop %s: %w", shop.ID, err)
+		}
+		if _, err := createReceiptByTemplate(set, shop, o, items, total); err != nil {
+			return nil, fmt.Errorf("shop %s: %w", shop.ID, err)
+		}
+	}
+	return set, nil
+}
+
+// orderModalData is the data of the order modal template.
+type orderModalData struct {
+	Shop      *catalog.Shop
+	Menu      []menuItemData
+	Cart      []cartLineData
+	Warning   string // Empty unless there's something to warn about.
+	Questions []inputData
+	AddOns    []inputData
+}
+
+type menuItemData struct {
+	ID    string
+	Name  string
+	Price string // e.g. "$ 7.00"
+}
+
+type cartLineData struct {
+	Menu string // The menu item ID
+	Name string // e.g. "Cheese Burger × 2"
+}
+
+type inputData struct {
+	BlockID     string
+	ActionID    string
+	Label       string
+	MultiSelect bool // A multi_static_select instead of checkboxes. Only for add-ons.
+	Options     []optionData
+}
+
+type optionData struct {
+	Value string
+	Text  string
+}
+
+// createOrderModalByTemplate makes a modal view by using the order modal template
+func createOrderModalByTemplate(set *templates.Set, shop *catalog.Shop, c cart, warning string) (*slack.ModalViewRequest, error) {
+	data := orderModalData{Shop: shop, Warning: warning}
+
+	for _, item := range shop.Menu {
+		data.Menu = append(data.Menu, menuItemData{ID: item.ID, Name: item.Name, Price: shop.Money(item.Price).String()})
+	}
+	for _, line := range c {
+		data.Cart = append(data.Cart, cartLineData{Menu: line.Menu, Name: cartLineName(shop, line)})
+	}
+
+	for _, q := range shop.Questions {
+		input := inputData{BlockID: questionBlockID(q), ActionID: questionActionID(q), Label: q.Label}
+		for _, c := range q.Choices {
+			input.Options = append(input.Options, optionData{Value: c.ID, Text: c.Name})
+		}
+		data.Questions = append(data.Questions, input)
+	}
+	for _, g := range shop.AddOns {
+		input := inputData{
+			BlockID:     addOnBlockID(g),
+			ActionID:    addOnActionID(g),
+			Label:       g.Label,
+			MultiSelect: g.Input == catalog.InputMultiSelect,
+		}
+		for _, o := range g.Options {
+			input.Options = append(input.Options, optionData{Value: o.ID, Text: addOnOptionName(shop, o)})
+		}
+		data.AddOns = append(data.AddOns, input)
+	}
+
+	return set.Modal(tmplOrderModal, data)
+}
+
+// receiptData is the data of the receipt template.
+type receiptData struct {
+	Shop    *catalog.Shop
+	Cart    []string // e.g. "Cheese Burger × 2"
+	Answers []sectionData
+	AddOns  []sectionData
+	Note    string
+	Items   []lineItemData
+	Total   string
+}
+
+type sectionData struct {
+	Label  string
+	Values []string // The name of the answer, or the names of the picked add-ons
+}
+
+type lineItemData struct {
+	Name   string
+	Amount string
+}
+
+// createReceiptByTemplate makes the blocks of a receipt by using the receipt template
+func createReceiptByTemplate(set *templates.Set, shop *catalog.Shop, o order, items []lineItem, total money.Money) ([]slack.Block, error) {
+	data := receiptData{Shop: shop, Note: o.Note, Total: total.String()}
+
+	for _, line := range o.Cart {
+		data.Cart = append(data.Cart, cartLineName(shop, line))
+	}
+	for _, q := range shop.Questions {
+		name := o.Answers[q.ID]
+		if c, ok := q.Choice(name); ok {
+			name = c.Name
+		}
+		data.Answers = append(data.Answers, sectionData{Label: q.Label, Values: []string{name}})
+	}
+	for _, g := range shop.AddOns {
+		section := sectionData{Label: g.Label}
+		for _, opt := range pickedOptions(g, o.AddOns) {
+			section.Values = append(section.Values, opt.Name)
+		}
+		data.AddOns = append(data.AddOns, section)
+	}
+	for _, item := range items {
+		data.Items = append(data.Items, lineItemData{Name: item.Name, Amount: item.Amount.String()})
+	}
+
+	return set.Message(tmplReceipt, data)
+}
@@ -0,0 +1,162 @@
+package interactiveapp
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nicoJN/slack-modal-examples/slackapp/catalog"
+	"github.com/nicoJN/slack-modal-examples/slackapp/money"
+	"github.com/nicoJN/slack-modal-examples/slackapp/slacktest"
+)
+
+// The templates shipped with the handlers.
+const templateDir = "../../templates"
+
+func equalJSON(t *testing.T, got, want interface{}) bool {
+	t.Helper()
+
+	g, err := json.Marshal(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w, err := json.Marshal(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(g) != string(w) {
+		t.Errorf("template renders\n%s\nwant\n%s", g, w)
+		return false
+	}
+	return true
+}
+
+// The templates must look the same as the modals and messages built in Go, so that neither drifts.
+func TestTemplatesMatchSDK(t *testing.T) {
+	cat, err := catalog.Load("../../catalog.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	set, err := LoadTemplates(templateDir, cat)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := range cat.Shops {
+		shop := &cat.Shops[i]
+		t.Run(shop.ID, func(t *testing.T) {
+			full := order{Shop: shop.ID, Answers: map[string]string{}, AddOns: map[string][]string{}, Note: "No \"onions\",\nplease <3"}
+			for _, item := range shop.Menu {
+				full.Cart = full.Cart.add(item.ID, 2)
+			}
+			for _, q := range shop.Questions {
+				full.Answers[q.ID] = q.Choices[len(q.Choices)-1].ID
+			}
+			for _, g := range shop.AddOns {
+				for _, o := range g.Options {
+					full.AddOns[g.ID] = append(full.AddOns[g.ID], o.ID)
+				}
+			}
+
+			for _, warning := range []string{"", "Add something to your cart before you submit."} {
+				for _, c := range []cart{nil, full.Cart} {
+					got, err := createOrderModalByTemplate(set, shop, c, warning)
+					if err != nil {
+						t.Fatal(err)
+					}
+					want, err := createOrderModalBySDK(shop, c, warning)
+					if err != nil {
+						t.Fatal(err)
+					}
+					if !equalJSON(t, got, want) {
+						return
+					}
+				}
+			}
+
+			for _, o := range []order{full, {Shop: shop.ID, Cart: cart{{Menu: shop.Menu[0].ID, Quantity: 1}}}} {
+				items, total, err := priceOrder(shop, o)
+				if err != nil {
+					t.Fatal(err)
+				}
+				items = append(items, lineItem{Name: "Chip", Amount: money.New(150, shop.Currency)})
+
+				got, err := createReceiptByTemplate(set, shop, o, items, total)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !equalJSON(t, got, createReceiptBySDK(shop, o, items, total)) {
+					return
+				}
+			}
+		})
+	}
+}
+
+func TestTemplatesInFlow(t *testing.T) {
+	cat, err := catalog.Load("../../catalog.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	set, err := LoadTemplates(templateDir, cat)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a, fake := newTestApp(t, WithTemplates(set))
+	modal := openOrderModal(t, a, fake)
+	if modal.Title.Text != "Hungryman Hamburgers" || modal.CallbackID != reqOrderModalSubmission {
+		t.Errorf("title = %q, callback ID = %q, want an order modal of the hamburger shop", modal.Title.Text, modal.CallbackID)
+	}
+
+	confirmation := submitOrder(t, a, fake, modal)
+	send(t, a, slacktest.ViewSubmission("U0001", confirmation, slacktest.Text("block_id_chip", "action_id_chip", "100")))
+
+	messages := fake.Messages()
+	if len(messages) != 1 {
+		t.Fatalf("posted %d messages, want 1", len(messages))
+	}
+	texts := strings.Join(slacktest.Texts(messages[0].Blocks), "\n")
+	for _, want := range []string{"Thank you for your order", "Cheese Burger × 1", "No pickles, please.", "Chip: $ 100.00", "Total: $ 800.00"} {
+		if !strings.Contains(texts, want) {
+			t.Errorf("receipt doesn't contain %q:\n%s", want, texts)
+		}
+	}
+}
+
+func TestBrokenTemplates(t *testing.T) {
+	cat, err := catalog.Load("../../catalog.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		files map[string]string
+	}{
+		{"no receipt", map[string]string{tmplOrderModal: `{"type": "modal", "title": {"type": "plain_text", "text": "Shop"}, "blocks": []}`}},
+		{"unknown field", map[string]string{tmplOrderModal: `{{.Shop.Owner}}`, tmplReceipt: `{"blocks": []}`}},
+		{"not Block Kit", map[string]string{tmplOrderModal: `{"type": "modal", "title": {"type": "plain_text", "text": "Shop"}, "blocks": [{"type": "button"}]}`, tmplReceipt: `{"blocks": []}`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "templates")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+
+			for name, text := range tt.files {
+				if err := ioutil.WriteFile(filepath.Join(dir, name+".json.tmpl"), []byte(text), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if _, err := LoadTemplates(dir, cat); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
@@ -2,47 +2,16 @@ package main
 
 import (
 	"context"
-	"encoding/json"
-	"log"
-	"net/http"
-	"net/url"
-	"strings"
 
-	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-lambda-go/lambda"
-	"github.com/slack-go/slack"
+	"github.com/nicoJN/slack-modal-examples/interactive/interactiveapp"
+	"github.com/nicoJN/slack-modal-examples/slackapp/catalog"
+	"github.com/nicoJN/slack-modal-examples/slackapp/config"
+	"github.com/nicoJN/slack-modal-examples/slackapp/logging"
+	"github.com/nicoJN/slack-modal-examples/slackapp/worker"
+	_ "modernc.org/sqlite"
 )
 
-var (
-	signingSecret = "YOUR_SIGNING_SECRET_HERE!"
-	tokenBotUser  = "YOUR_BOT_USER_OAUTH_ACCESS_TOKEN_HERE!"
-
-	reqButtonPushedAction          = "buttonPushedAction"
-	reqOrderModalSubmission        = "orderModalSubmission"
-	reqConfirmationModalSubmission = "confirmationModalSubmission"
-	reqUnknown                     = "unknown"
-
-	burgers = map[string]string{
-		"hamburger":     "Hamburger",
-		"cheese_burger": "Cheese Burger",
-		"blt_burger":    "BLT Burger",
-		"big_burger":    "Big burger",
-		"king_burger":   "King burger",
-	}
-)
-
-type privateMeta struct {
-	ChannelID string `json:"channel_id"`
-	order
-}
-
-type order struct {
-	Menu   string `json:"order_menu"`
-	Steak  string `json:"order_steak"`
-	Note   string `json:"order_note"`
-	Amount string `json:"order_amount"`
-}
-
 func main() {
 	// NOTE: In this example, we use 4 handlers. You should see what you want to know.
 	// 1. Receive a message to call a bot and send an interactive message with button -> handleEventRequest()
@@ -50,93 +19,50 @@ func main() {
 	// 3. Receive an order modal submission message and send a confirmation modal -> handleOrderModalSubmissionRequest()
 	// 4. Receive a confirmation modal submission message and send a complession message -> handleConfirmationModalSubmissionRequest()
 
-	lambda.Start(handleInteractiveRequest)
-}
-
-func handleInteractiveRequest(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
-	// Verify the request.
-	if err := verify(request, signingSecret); err != nil {
-		log.Printf("[ERROR] Failed to verify request: %v", err)
-		return events.APIGatewayProxyResponse{StatusCode: 200}, nil
-	}
-
-	// Parse the request
-	payload, err := url.QueryUnescape(request.Body)
+	// Load the settings at cold start.
+	cfg, err := config.LoadDefault()
 	if err != nil {
-		log.Printf("[ERROR] Failed to unescape: %v", err)
-		return events.APIGatewayProxyResponse{StatusCode: 200}, nil
+		logging.Default().Fatal("Failed to load config", logging.KeyError, err)
 	}
-	payload = strings.Replace(payload, "payload=", "", 1)
-
-	var message slack.InteractionCallback
-	if err := json.Unmarshal([]byte(payload), &message); err != nil {
-		log.Printf("[ERROR] Failed to unmarshal json: %v", err)
-		return events.APIGatewayProxyResponse{StatusCode: 200}, nil
+	if err := logging.Configure(cfg.LogLevel); err != nil {
+		logging.Default().Fatal("Failed to configure logging", logging.KeyError, err)
 	}
 
-	// Identify the request type and dispatch message to appropreate handlers.
-	switch identifyRequestType(message) {
-	case reqButtonPushedAction:
-		res, err := handleButtonPushedRequest(message)
-		if err != nil {
-			log.Printf("[ERROR] Failed to handle button pushed action: %v", err)
-			return events.APIGatewayProxyResponse{StatusCode: 200}, nil
-		}
-		return res, nil
-	case reqOrderModalSubmission:
-		res, err := handleOrderSubmissionRequest(message)
-		if err != nil {
-			log.Printf("[ERROR] Failed to handle order submission: %v", err)
-			return events.APIGatewayProxyResponse{StatusCode: 200}, nil
-		}
-		return res, nil
-	case reqConfirmationModalSubmission:
-		res, err := handleConfirmationModalSubmissionRequest(message)
-		if err != nil {
-			log.Printf("[ERROR] Failed to handle confirmation modal submission: %v", err)
-			return events.APIGatewayProxyResponse{StatusCode: 200}, nil
-		}
-		return res, nil
-	default:
-		log.Printf("[ERROR] unknown request type: %v", message.Type)
-		return events.APIGatewayProxyResponse{StatusCode: 200}, nil
+	cat, err := catalog.Load(cfg.CatalogFile)
+	if err != nil {
+		logging.Default().Fatal("Failed to load catalog", logging.KeyError, err)
 	}
-}
 
-// verify returns the result of slack signing secret verification.
-func verify(request events.APIGatewayProxyRequest, sc string) error {
-	body := request.Body
-	header := http.Header{}
-	for k, v := range request.Headers {
-		header.Set(k, v)
+	// The next request of an order may reach another container, so the orders in progress must be
+	// kept in a store which the containers share.
+	if cfg.SessionTable == "" && cfg.SessionDB == "" {
+		logging.Default().Fatal("Lambda containers need a shared session store", "key", config.KeySessionTable+" or "+config.KeySessionDB)
 	}
-
-	sv, err := slack.NewSecretsVerifier(header, sc)
+	sessions, err := interactiveapp.NewSessionStore(context.Background(), cfg)
 	if err != nil {
-		return err
+		logging.Default().Fatal("Failed to open the session store", logging.KeyError, err)
 	}
 
-	sv.Write([]byte(body))
-	return sv.Ensure()
-}
-
-// identifyRequestType returns the request type of a slack message.
-func identifyRequestType(message slack.InteractionCallback) string {
-
-	// Check if the request is button pushed message.
-	if message.Type == slack.InteractionTypeBlockActions && message.View.Hash == "" {
-		return reqButtonPushedAction
+	// The slow work runs in another invocation of this function, because the process is frozen once
+	// the request is acknowledged.
+	queue, err := worker.NewSelfInvokeQueue()
+	if err != nil {
+		logging.Default().Fatal("Failed to create a job queue", logging.KeyError, err)
 	}
-
-	// Check if the request is order modal submission.
-	if message.Type == slack.InteractionTypeViewSubmission && strings.Contains(message.View.CallbackID, reqOrderModalSubmission) {
-		return reqOrderModalSubmission
+	opts := []interactiveapp.Option{
+		interactiveapp.WithQueue(func(worker.Runner) worker.Queue {
+			return queue
+		}),
+		interactiveapp.WithSessionStore(sessions),
 	}
-
-	// Check if the request is confirmation modal submission.
-	if message.Type == slack.InteractionTypeViewSubmission && strings.Contains(message.View.CallbackID, reqConfirmationModalSubmission) {
-		return reqConfirmationModalSubmission
+	if cfg.TemplateDir != "" {
+		set, err := interactiveapp.LoadTemplates(cfg.TemplateDir, cat)
+		if err != nil {
+			logging.Default().Fatal("Failed to load templates", logging.KeyError, err)
+		}
+		opts = append(opts, interactiveapp.WithTemplates(set))
 	}
+	app := interactiveapp.New(cfg, cat, opts...)
 
-	return reqUnknown
+	lambda.Start(worker.LambdaHandler(app.HandleInteractiveRequest, app.Jobs()))
 }
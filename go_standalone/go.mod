@@ -0,0 +1,18 @@
+module github.com/nicoJN/slack-modal-examples/standalone
+
+go 1.14
+
+require (
+	github.com/aws/aws-lambda-go v1.19.1
+	github.com/nicoJN/slack-modal-examples/event v0.0.0
+	github.com/nicoJN/slack-modal-examples/interactive v0.0.0
+	github.com/nicoJN/slack-modal-examples/slackapp v0.0.0
+	github.com/slack-go/slack v0.6.6
+	modernc.org/sqlite v1.10.6
+)
+
+replace (
+	github.com/nicoJN/slack-modal-examples/event => ../go_event_message
+	github.com/nicoJN/slack-modal-examples/interactive => ../go_interactive_message
+	github.com/nicoJN/slack-modal-examples/slackapp => ../slackapp
+)
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/nicoJN/slack-modal-examples/event/eventapp"
+	"github.com/nicoJN/slack-modal-examples/interactive/interactiveapp"
+	"github.com/nicoJN/slack-modal-examples/slackapp"
+	"github.com/nicoJN/slack-modal-examples/slackapp/catalog"
+	"github.com/nicoJN/slack-modal-examples/slackapp/config"
+	"github.com/nicoJN/slack-modal-examples/slackapp/logging"
+	"github.com/nicoJN/slack-modal-examples/slackapp/socketmode"
+	_ "modernc.org/sqlite"
+)
+
+func main() {
+	// NOTE: This binary runs both handlers outside AWS Lambda.
+	// By default, it serves them as a plain HTTP server for local development.
+	// Set these URLs in your Slack app settings (e.g. through a tunnel like ngrok).
+	// - Event Subscriptions : http://YOUR_HOST/slack/events
+	// - Interactivity       : http://YOUR_HOST/slack/interactive
+	//
+	// With -socket-mode, it receives them over a WebSocket instead and needs no public endpoint.
+	addr := flag.String("addr", ":3000", "address to listen on")
+	useSocketMode := flag.Bool("socket-mode", false, "receive events and interactions over Socket Mode")
+	flag.Parse()
+
+	// Load the settings at start.
+	cfg, err := config.LoadDefault()
+	if err != nil {
+		logging.Default().Fatal("Failed to load config", logging.KeyError, err)
+	}
+	if err := logging.Configure(cfg.LogLevel); err != nil {
+		logging.Default().Fatal("Failed to configure logging", logging.KeyError, err)
+	}
+
+	cat, err := catalog.Load(cfg.CatalogFile)
+	if err != nil {
+		logging.Default().Fatal("Failed to load catalog", logging.KeyError, err)
+	}
+
+	sessions, err := interactiveapp.NewSessionStore(context.Background(), cfg)
+	if err != nil {
+		logging.Default().Fatal("Failed to open the session store", logging.KeyError, err)
+	}
+
+	event := eventapp.New(cfg, cat)
+	opts := []interactiveapp.Option{interactiveapp.WithSessionStore(sessions)}
+	if cfg.TemplateDir != "" {
+		set, err := interactiveapp.LoadTemplates(cfg.TemplateDir, cat)
+		if err != nil {
+			logging.Default().Fatal("Failed to load templates", logging.KeyError, err)
+		}
+		opts = append(opts, interactiveapp.WithTemplates(set))
+	}
+	interactive := interactiveapp.New(cfg, cat, opts...)
+
+	if *useSocketMode && cfg.AppToken == "" {
+		logging.Default().Fatal("Socket Mode needs an app-level token", "key", config.KeyAppToken)
+	}
+
+	// Stop on Ctrl+C or SIGTERM.
+	ctx, cancel := context.WithCancel(context.Background())
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-sig
+		cancel()
+	}()
+
+	if *useSocketMode {
+		client := socketmode.New(cfg.AppToken, event.HandleEventRequest, interactive.HandleInteractiveRequest)
+		err = client.Run(ctx)
+		if err == context.Canceled {
+			err = nil
+		}
+	} else {
+		err = listenAndServe(ctx, *addr, newServeMux(event, interactive))
+	}
+
+	// Finish the slow work in progress before exiting.
+	interactive.Close()
+	if err != nil {
+		logging.Default().Fatal("Failed to serve", logging.KeyError, err)
+	}
+}
+
+// shutdownTimeout is how long the requests in progress may take once the server is stopped.
+const shutdownTimeout = 10 * time.Second
+
+// listenAndServe serves h on addr until ctx ends.
+func listenAndServe(ctx context.Context, addr string, h http.Handler) error {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+	logging.Default().Info("Listening", "addr", addr)
+	return serve(ctx, ln, h)
+}
+
+// serve serves h on ln until ctx ends, and then shuts the server down gracefully.
+func serve(ctx context.Context, ln net.Listener, h http.Handler) error {
+	server := &http.Server{Handler: h}
+	errc := make(chan error, 1)
+	go func() {
+		errc <- server.Serve(ln)
+	}()
+
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+	}
+
+	logging.Default().Info("Shutting down")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("failed to shut down: %w", err)
+	}
+	return nil
+}
+
+// newServeMux exposes both handlers.
+func newServeMux(event *eventapp.App, interactive *interactiveapp.App) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.Handle("/slack/events", slackapp.HTTPHandler(event.HandleEventRequest))
+	mux.Handle("/slack/interactive", slackapp.HTTPHandler(interactive.HandleInteractiveRequest))
+	return mux
+}
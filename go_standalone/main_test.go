@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/nicoJN/slack-modal-examples/event/eventapp"
+	"github.com/nicoJN/slack-modal-examples/interactive/interactiveapp"
+	"github.com/nicoJN/slack-modal-examples/slackapp/catalog"
+	"github.com/nicoJN/slack-modal-examples/slackapp/config"
+	"github.com/nicoJN/slack-modal-examples/slackapp/slackapi/slackapitest"
+	"github.com/nicoJN/slack-modal-examples/slackapp/slacktest"
+	"github.com/slack-go/slack"
+)
+
+// post sends a request built for API Gateway to the server over HTTP.
+func post(t *testing.T, url string, request events.APIGatewayProxyRequest) {
+	t.Helper()
+
+	req, err := http.NewRequest("POST", url, strings.NewReader(request.Body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k, v := range request.Headers {
+		req.Header.Set(k, v)
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("status code = %d, want 200", res.StatusCode)
+	}
+}
+
+func TestMentionToOrderModal(t *testing.T) {
+	fake := slackapitest.NewFake()
+	cfg := &config.Config{SigningSecret: slacktest.SigningSecret, BotToken: "xoxb-test"}
+	cat, err := catalog.Load("../catalog.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	interactive := interactiveapp.New(cfg, cat, interactiveapp.WithSlackClient(fake))
+	server := httptest.NewServer(newServeMux(eventapp.New(cfg, cat, eventapp.WithSlackClient(fake)), interactive))
+	defer server.Close()
+
+	// 1. Mention -> shop list
+	request, err := slacktest.NewEventRequest(slacktest.SigningSecret, slacktest.AppMention("C0001", "U0001", "<@B0001>"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	post(t, server.URL+"/slack/events", request)
+
+	messages := fake.Messages()
+	if len(messages) != 1 {
+		t.Fatalf("posted %d messages, want 1", len(messages))
+	}
+
+	// Push the first button in the shop list.
+	var button *slack.ButtonBlockElement
+	for _, b := range messages[0].Blocks.BlockSet {
+		if s, ok := b.(*slack.SectionBlock); ok && s.Accessory != nil && s.Accessory.ButtonElement != nil {
+			button = s.Accessory.ButtonElement
+			break
+		}
+	}
+	if button == nil {
+		t.Fatal("shop list has no buttons")
+	}
+
+	// 2. Button pushed -> order modal
+	request, err = slacktest.NewInteractionRequest(slacktest.SigningSecret, slacktest.ButtonPushed("U0001", "C0001", button.ActionID, button.Value))
+	if err != nil {
+		t.Fatal(err)
+	}
+	post(t, server.URL+"/slack/interactive", request)
+
+	views := fake.OpenedViews()
+	if len(views) != 1 {
+		t.Fatalf("opened %d views, want 1", len(views))
+	}
+	if views[0].View.Title.Text != "Hungryman Hamburgers" {
+		t.Errorf("title = %q, want Hungryman Hamburgers", views[0].View.Title.Text)
+	}
+}
+
+func TestServeShutsDown(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	started, release := make(chan struct{}), make(chan struct{})
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		w.Write([]byte("done"))
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errc := make(chan error, 1)
+	go func() {
+		errc <- serve(ctx, ln, h)
+	}()
+
+	body := make(chan string, 1)
+	go func() {
+		res, err := http.Get("http://" + ln.Addr().String())
+		if err != nil {
+			body <- err.Error()
+			return
+		}
+		defer res.Body.Close()
+		b, _ := ioutil.ReadAll(res.Body)
+		body <- string(b)
+	}()
+
+	// The request in progress finishes after the server is stopped.
+	<-started
+	cancel()
+	close(release)
+
+	if got := <-body; got != "done" {
+		t.Errorf("body = %q, want done", got)
+	}
+	if err := <-errc; err != nil {
+		t.Errorf("err = %v, want nil", err)
+	}
+}
@@ -0,0 +1,96 @@
+package slackapp
+
+import (
+	"encoding/base64"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/nicoJN/slack-modal-examples/slackapp/logging"
+)
+
+// HTTPHandler converts a Handler into an http.Handler.
+func HTTPHandler(h Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request, err := NewProxyRequest(r)
+		if err != nil {
+			logging.FromContext(r.Context()).Error("Failed to read request", logging.KeyError, err)
+			http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+			return
+		}
+
+		response, err := h(r.Context(), request)
+		if err != nil {
+			logging.FromContext(r.Context()).Error("Failed to handle request", logging.KeyError, err)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+
+		if err := WriteProxyResponse(w, response); err != nil {
+			logging.FromContext(r.Context()).Error("Failed to write response", logging.KeyError, err)
+		}
+	})
+}
+
+// NewProxyRequest converts an http.Request into the request API Gateway would send.
+func NewProxyRequest(r *http.Request) (events.APIGatewayProxyRequest, error) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return events.APIGatewayProxyRequest{}, err
+	}
+
+	headers := map[string]string{}
+	multiHeaders := map[string][]string{}
+	for k, v := range r.Header {
+		headers[k] = v[0]
+		multiHeaders[k] = v
+	}
+
+	query := map[string]string{}
+	multiQuery := map[string][]string{}
+	for k, v := range r.URL.Query() {
+		query[k] = v[0]
+		multiQuery[k] = v
+	}
+
+	return events.APIGatewayProxyRequest{
+		Resource:                        r.URL.Path,
+		Path:                            r.URL.Path,
+		HTTPMethod:                      r.Method,
+		Headers:                         headers,
+		MultiValueHeaders:               multiHeaders,
+		QueryStringParameters:           query,
+		MultiValueQueryStringParameters: multiQuery,
+		Body:                            string(body),
+	}, nil
+}
+
+// WriteProxyResponse writes a response returned by a Handler.
+func WriteProxyResponse(w http.ResponseWriter, response events.APIGatewayProxyResponse) error {
+	for k, v := range response.Headers {
+		w.Header().Set(k, v)
+	}
+	for k, vs := range response.MultiValueHeaders {
+		for _, v := range vs {
+			w.Header().Add(k, v)
+		}
+	}
+
+	body := []byte(response.Body)
+	if response.IsBase64Encoded {
+		b, err := base64.StdEncoding.DecodeString(response.Body)
+		if err != nil {
+			return err
+		}
+		body = b
+	}
+
+	status := response.StatusCode
+	if status == 0 {
+		status = http.StatusOK
+	}
+	w.WriteHeader(status)
+
+	_, err := w.Write(body)
+	return err
+}
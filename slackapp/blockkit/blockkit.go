@@ -0,0 +1,518 @@
+// Package blockkit checks modals and messages against Block Kit before they're sent. Slack rejects a
+// view or a message which isn't well formed or breaks a limit with a terse invalid_arguments or
+// invalid_blocks error, and often only once a user has ordered more than anyone tried in development.
+//
+// The checks run offline over the JSON which would be sent, and report every violation with its path:
+//
+//	blocks[3].element.options[0].text: 160 characters, more than 150
+//	blocks[4].element.type: "button" can't be used here
+//
+// The schema is the subset of Block Kit which the slack package can decode: the types of blocks,
+// elements and objects, their required fields, the fields they may have and the limits below.
+package blockkit
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/slack-go/slack"
+)
+
+// Limits of Block Kit. Lengths are in characters.
+const (
+	MaxModalBlocks     = 100
+	MaxMessageBlocks   = 50
+	MaxPrivateMetadata = 3000
+	MaxTitle           = 24 // The title, submit and close of a modal
+	MaxID              = 255
+	MaxSectionText     = 3000
+	MaxSectionFields   = 10
+	MaxFieldText       = 2000
+	MaxLabel           = 2000 // The label and the hint of an input
+	MaxContextElements = 10
+	MaxActionElements  = 25
+	MaxButtonText      = 75
+	MaxPlaceholder     = 150
+	MaxOptions         = 100
+	MaxChoiceOptions   = 10 // Options of radio buttons and checkboxes
+	MaxOptionText      = 150
+	MaxInputLength     = 3000
+)
+
+// Violation is a part of a view or a message which isn't valid Block Kit.
+type Violation struct {
+	Path    string // The JSON path, e.g. "blocks[3].element.options[0].text"
+	Message string // e.g. "160 characters, more than 150"
+}
+
+func (v Violation) String() string {
+	if v.Path == "" {
+		return v.Message
+	}
+	return v.Path + ": " + v.Message
+}
+
+// Violations are every violation in a view or a message.
+type Violations []Violation
+
+func (v Violations) Error() string {
+	msgs := make([]string, len(v))
+	for i, violation := range v {
+		msgs[i] = violation.String()
+	}
+	return "blockkit: " + strings.Join(msgs, "; ")
+}
+
+// ValidateView checks a modal. It returns Violations, or nil when the modal is valid.
+func ValidateView(view slack.ModalViewRequest) error {
+	var v interface{}
+	if err := remarshal(view, &v); err != nil {
+		return err
+	}
+	return ValidateDecodedView(v)
+}
+
+// ValidateDecodedView checks a modal decoded from JSON into interface{} values, e.g. one rendered from a
+// template. It returns Violations, or nil when the modal is valid.
+func ValidateDecodedView(v interface{}) error {
+	c := &checker{}
+	c.view(v)
+	return c.err()
+}
+
+// ValidateBlocks checks the blocks of a message. It returns Violations, or nil when the blocks are valid.
+func ValidateBlocks(blocks []slack.Block) error {
+	var list interface{}
+	if err := remarshal(blocks, &list); err != nil {
+		return err
+	}
+
+	c := &checker{}
+	c.blocks("blocks", list, MaxMessageBlocks)
+	return c.err()
+}
+
+// ValidateMessage checks the blocks set by the options of a message, e.g. slack.MsgOptionBlocks.
+func ValidateMessage(options ...slack.MsgOption) error {
+	_, values, err := slack.UnsafeApplyMsgOptions("", "", "", options...)
+	if err != nil {
+		return fmt.Errorf("blockkit: failed to apply message options: %w", err)
+	}
+	b := values.Get("blocks")
+	if b == "" {
+		return nil
+	}
+
+	var list interface{}
+	if err := json.Unmarshal([]byte(b), &list); err != nil {
+		return fmt.Errorf("blockkit: failed to unmarshal blocks: %w", err)
+	}
+
+	c := &checker{}
+	c.blocks("blocks", list, MaxMessageBlocks)
+	return c.err()
+}
+
+// ValidateDecodedMessage checks an object with the blocks of a message, decoded from JSON into
+// interface{} values. It returns Violations, or nil when the blocks are valid.
+func ValidateDecodedMessage(v interface{}) error {
+	c := &checker{}
+	o, ok := v.(object)
+	if !ok {
+		c.add("", "not an object")
+		return c.err()
+	}
+	if c.fields("", o, shape{required: []string{"blocks"}}) {
+		c.blocks("blocks", o["blocks"], MaxMessageBlocks)
+	}
+	return c.err()
+}
+
+// remarshal converts v to the JSON which would be sent, decoded into dst.
+func remarshal(v, dst interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("blockkit: failed to marshal: %w", err)
+	}
+	if err := json.Unmarshal(b, dst); err != nil {
+		return fmt.Errorf("blockkit: failed to unmarshal: %w", err)
+	}
+	return nil
+}
+
+// object is a decoded JSON object.
+type object = map[string]interface{}
+
+// shape is the fields of a kind of object. check checks the values of the fields besides the type, and
+// runs only when the required fields are there.
+type shape struct {
+	required []string
+	optional []string
+	check    func(c *checker, path string, o object)
+}
+
+var textTypes = []string{"plain_text", "mrkdwn"}
+
+var (
+	viewShape = shape{
+		required: []string{"title", "blocks"},
+		optional: []string{"close", "submit", "private_metadata", "callback_id", "clear_on_close", "notify_on_close", "external_id"},
+	}
+	textShape   = shape{required: []string{"type", "text"}, optional: []string{"emoji", "verbatim"}}
+	optionShape = shape{required: []string{"text", "value"}, optional: []string{"description", "url"}}
+)
+
+var blocks = map[string]shape{
+	"section": {optional: []string{"block_id", "text", "fields", "accessory"}, check: (*checker).section},
+	"divider": {optional: []string{"block_id"}},
+	"context": {required: []string{"elements"}, optional: []string{"block_id"}, check: (*checker).context},
+	"actions": {required: []string{"elements"}, optional: []string{"block_id"}, check: (*checker).actions},
+	"input":   {required: []string{"label", "element"}, optional: []string{"block_id", "hint", "optional"}, check: (*checker).input},
+	"image":   {required: []string{"image_url", "alt_text"}, optional: []string{"block_id", "title"}},
+	"file":    {required: []string{"external_id", "source"}, optional: []string{"block_id"}},
+}
+
+var elements = map[string]shape{
+	"button":              {required: []string{"text"}, optional: []string{"action_id", "url", "value", "style", "confirm"}, check: (*checker).button},
+	"static_select":       {optional: []string{"placeholder", "action_id", "options", "option_groups", "initial_option", "confirm"}, check: (*checker).selectMenu},
+	"multi_static_select": {optional: []string{"placeholder", "action_id", "options", "option_groups", "initial_options", "confirm", "max_selected_items"}, check: (*checker).selectMenu},
+	"overflow":            {required: []string{"options"}, optional: []string{"action_id", "confirm"}, check: (*checker).overflow},
+	"datepicker":          {optional: []string{"action_id", "placeholder", "initial_date", "confirm"}},
+	"plain_text_input":    {optional: []string{"action_id", "placeholder", "initial_value", "multiline", "min_length", "max_length"}, check: (*checker).plainTextInput},
+	"radio_buttons":       {required: []string{"options"}, optional: []string{"action_id", "initial_option", "confirm"}, check: (*checker).choices},
+	"checkboxes":          {required: []string{"options"}, optional: []string{"action_id", "initial_options", "confirm"}, check: (*checker).choices},
+	"image":               {required: []string{"image_url", "alt_text"}},
+}
+
+// Elements which can be used in each place.
+var (
+	inputElements     = []string{"static_select", "multi_static_select", "datepicker", "plain_text_input", "radio_buttons", "checkboxes"}
+	actionElements    = []string{"button", "static_select", "overflow", "datepicker", "radio_buttons", "checkboxes"}
+	accessoryElements = []string{"button", "static_select", "multi_static_select", "overflow", "datepicker", "radio_buttons", "checkboxes", "image"}
+)
+
+// checker collects violations while it walks through the decoded JSON.
+type checker struct {
+	violations Violations
+	actionIDs  map[string]string // The paths of the action IDs in the current block
+}
+
+func (c *checker) err() error {
+	if len(c.violations) > 0 {
+		return c.violations
+	}
+	return nil
+}
+
+func (c *checker) add(path, format string, args ...interface{}) {
+	c.violations = append(c.violations, Violation{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (c *checker) length(path, s string, max int) {
+	if n := utf8.RuneCountInString(s); n > max {
+		c.add(path, "%d characters, more than %d", n, max)
+	}
+}
+
+func (c *checker) count(path string, list []interface{}, max int, what string) {
+	if len(list) > max {
+		c.add(path, "%d %s, more than %d", len(list), what, max)
+	}
+}
+
+// fields checks that o has the required fields of s and no unknown fields. It reports whether the
+// required fields are there.
+func (c *checker) fields(path string, o object, s shape) bool {
+	ok := true
+	for _, key := range s.required {
+		if _, has := o[key]; !has {
+			c.add(join(path, key), "missing")
+			ok = false
+		}
+	}
+
+	var keys []string
+	for key := range o {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if key != "type" && !contains(s.required, key) && !contains(s.optional, key) {
+			c.add(path, "unknown field %s", key)
+		}
+	}
+	return ok
+}
+
+// text checks a text object whose type is one of types, and whose text is at most max characters long
+// unless max is 0.
+func (c *checker) text(path string, v interface{}, max int, types ...string) {
+	o, ok := v.(object)
+	if !ok {
+		c.add(path, "not a text object")
+		return
+	}
+	if !c.fields(path, o, textShape) {
+		return
+	}
+	if typ, _ := o["type"].(string); !contains(types, typ) {
+		c.add(path+".type", "%q isn't %s", o["type"], strings.Join(types, " or "))
+	}
+	s, _ := o["text"].(string)
+	if s == "" {
+		c.add(path+".text", "empty")
+		return
+	}
+	if max > 0 {
+		c.length(path, s, max)
+	}
+}
+
+// str checks that the field key of o is a string at most max characters long, if o has it.
+func (c *checker) str(path string, o object, key string, max int) {
+	v, ok := o[key]
+	if !ok {
+		return
+	}
+	s, ok := v.(string)
+	if !ok {
+		c.add(join(path, key), "not a string")
+		return
+	}
+	c.length(join(path, key), s, max)
+}
+
+func (c *checker) view(v interface{}) {
+	o, ok := v.(object)
+	if !ok {
+		c.add("", "not an object")
+		return
+	}
+	if o["type"] != "modal" {
+		c.add("type", "%v isn't modal", o["type"])
+	}
+	c.fields("", o, viewShape)
+
+	for _, key := range []string{"title", "submit", "close"} {
+		if t, ok := o[key]; ok {
+			c.text(key, t, MaxTitle, "plain_text")
+		}
+	}
+	c.str("", o, "private_metadata", MaxPrivateMetadata)
+	c.str("", o, "callback_id", MaxID)
+	c.str("", o, "external_id", MaxID)
+	if b, ok := o["blocks"]; ok {
+		c.blocks("blocks", b, MaxModalBlocks)
+	}
+}
+
+func (c *checker) blocks(path string, v interface{}, max int) {
+	list, ok := v.([]interface{})
+	if !ok {
+		c.add(path, "not an array")
+		return
+	}
+	c.count(path, list, max, "blocks")
+
+	seen := map[string]string{}
+	for i, b := range list {
+		p := fmt.Sprintf("%s[%d]", path, i)
+		block, ok := b.(object)
+		if !ok {
+			c.add(p, "not an object")
+			continue
+		}
+
+		if id := str(block["block_id"]); id != "" {
+			if first, ok := seen[id]; ok {
+				c.add(p+".block_id", "%q is also the block ID of %s", id, first)
+			} else {
+				seen[id] = p
+			}
+			c.length(p+".block_id", id, MaxID)
+		}
+
+		// Action IDs must be unique in a block.
+		c.actionIDs = map[string]string{}
+		c.typed(p, block, blocks, nil)
+	}
+}
+
+// typed checks an object whose type is one of shapes, and one of allowed unless allowed is nil.
+func (c *checker) typed(path string, v interface{}, shapes map[string]shape, allowed []string) {
+	o, ok := v.(object)
+	if !ok {
+		c.add(path, "not an object")
+		return
+	}
+	typ, _ := o["type"].(string)
+	s, ok := shapes[typ]
+	if !ok || (allowed != nil && !contains(allowed, typ)) {
+		c.add(path+".type", "%q can't be used here", typ)
+		return
+	}
+	if c.fields(path, o, s) && s.check != nil {
+		s.check(c, path, o)
+	}
+}
+
+// element checks an element which is one of allowed.
+func (c *checker) element(path string, v interface{}, allowed []string) {
+	e, _ := v.(object)
+	if id := str(e["action_id"]); id != "" {
+		if first, ok := c.actionIDs[id]; ok {
+			c.add(path+".action_id", "%q is also the action ID of %s", id, first)
+		} else {
+			c.actionIDs[id] = path
+		}
+		c.length(path+".action_id", id, MaxID)
+	}
+	if p, ok := e["placeholder"]; ok {
+		c.text(path+".placeholder", p, MaxPlaceholder, "plain_text")
+	}
+	c.typed(path, v, elements, allowed)
+}
+
+func (c *checker) section(path string, o object) {
+	_, hasText := o["text"]
+	_, hasFields := o["fields"]
+	if !hasText && !hasFields {
+		c.add(path, "missing text or fields")
+	}
+	if hasText {
+		c.text(path+".text", o["text"], MaxSectionText, textTypes...)
+	}
+	if hasFields {
+		fields, ok := o["fields"].([]interface{})
+		if !ok {
+			c.add(path+".fields", "not an array")
+		}
+		c.count(path+".fields", fields, MaxSectionFields, "fields")
+		for i, f := range fields {
+			c.text(fmt.Sprintf("%s.fields[%d]", path, i), f, MaxFieldText, textTypes...)
+		}
+	}
+	if a, ok := o["accessory"]; ok {
+		c.element(path+".accessory", a, accessoryElements)
+	}
+}
+
+func (c *checker) context(path string, o object) {
+	list, ok := o["elements"].([]interface{})
+	if !ok || len(list) == 0 {
+		c.add(path+".elements", "not a non-empty array")
+		return
+	}
+	c.count(path+".elements", list, MaxContextElements, "elements")
+	for i, e := range list {
+		p := fmt.Sprintf("%s.elements[%d]", path, i)
+		if m, ok := e.(object); ok && m["type"] == "image" {
+			c.typed(p, e, elements, []string{"image"})
+			continue
+		}
+		c.text(p, e, 0, textTypes...)
+	}
+}
+
+func (c *checker) actions(path string, o object) {
+	list, ok := o["elements"].([]interface{})
+	if !ok || len(list) == 0 {
+		c.add(path+".elements", "not a non-empty array")
+		return
+	}
+	c.count(path+".elements", list, MaxActionElements, "elements")
+	for i, e := range list {
+		c.element(fmt.Sprintf("%s.elements[%d]", path, i), e, actionElements)
+	}
+}
+
+func (c *checker) input(path string, o object) {
+	c.text(path+".label", o["label"], MaxLabel, "plain_text")
+	if h, ok := o["hint"]; ok {
+		c.text(path+".hint", h, MaxLabel, "plain_text")
+	}
+	if opt, ok := o["optional"]; ok {
+		if _, ok := opt.(bool); !ok {
+			c.add(path+".optional", "not a boolean")
+		}
+	}
+	c.element(path+".element", o["element"], inputElements)
+}
+
+func (c *checker) button(path string, o object) {
+	c.text(path+".text", o["text"], MaxButtonText, "plain_text")
+}
+
+func (c *checker) plainTextInput(path string, o object) {
+	if n, ok := o["max_length"].(float64); ok && n > MaxInputLength {
+		c.add(path+".max_length", "%v, more than %d", n, MaxInputLength)
+	}
+}
+
+func (c *checker) selectMenu(path string, o object) {
+	if _, ok := o["options"]; ok {
+		c.options(path, o, MaxOptions)
+		return
+	}
+	if _, ok := o["option_groups"]; !ok {
+		c.add(path, "missing options or option_groups")
+	}
+}
+
+func (c *checker) overflow(path string, o object) {
+	c.options(path, o, MaxOptions)
+}
+
+// choices checks radio buttons and checkboxes.
+func (c *checker) choices(path string, o object) {
+	c.options(path, o, MaxChoiceOptions)
+}
+
+func (c *checker) options(path string, o object, max int) {
+	list, ok := o["options"].([]interface{})
+	if !ok || len(list) == 0 {
+		c.add(path+".options", "not a non-empty array")
+		return
+	}
+	c.count(path+".options", list, max, "options")
+	for i, v := range list {
+		p := fmt.Sprintf("%s.options[%d]", path, i)
+		opt, ok := v.(object)
+		if !ok {
+			c.add(p, "not an object")
+			continue
+		}
+		if !c.fields(p, opt, optionShape) {
+			continue
+		}
+		c.text(p+".text", opt["text"], MaxOptionText, textTypes...)
+		if value, _ := opt["value"].(string); value == "" {
+			c.add(p+".value", "empty")
+		}
+	}
+}
+
+// join appends key to path, which is empty at the top level.
+func join(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func str(v interface{}) string {
+	s, _ := v.(string)
+	return s
+}
+
+func contains(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
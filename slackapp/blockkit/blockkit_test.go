@@ -0,0 +1,145 @@
+package blockkit_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/nicoJN/slack-modal-examples/slackapp/blockkit"
+	"github.com/slack-go/slack"
+)
+
+func plain(s string) *slack.TextBlockObject {
+	return slack.NewTextBlockObject("plain_text", s, false, false)
+}
+
+func section(blockID, s string) *slack.SectionBlock {
+	return slack.NewSectionBlock(slack.NewTextBlockObject("mrkdwn", s, false, false), nil, nil, slack.SectionBlockOptionBlockID(blockID))
+}
+
+func modal(blocks ...slack.Block) slack.ModalViewRequest {
+	return slack.ModalViewRequest{
+		Type:   slack.ViewType("modal"),
+		Title:  plain("Hungryman Hamburgers"),
+		Submit: plain("Submit"),
+		Blocks: slack.Blocks{BlockSet: blocks},
+	}
+}
+
+func TestValidView(t *testing.T) {
+	options := []*slack.OptionBlockObject{slack.NewOptionBlockObject("medium", plain("medium"))}
+	m := modal(
+		section("block_id_menu", "*Hamburger*\n$ 5.00"),
+		slack.NewInputBlock("block_id_steak", plain("How do you like your steak?"), slack.NewOptionsSelectBlockElement("static_select", nil, "action_id_steak", options...)),
+	)
+	m.PrivateMetadata = strings.Repeat("あ", blockkit.MaxPrivateMetadata)
+
+	if err := blockkit.ValidateView(m); err != nil {
+		t.Errorf("err = %v, want none", err)
+	}
+}
+
+func TestViewViolations(t *testing.T) {
+	var options []*slack.OptionBlockObject
+	for _, name := range []string{"a", strings.Repeat("b", 151)} {
+		options = append(options, slack.NewOptionBlockObject(name[:1], plain(name)))
+	}
+	m := modal(
+		section("block_id_menu", "Hamburger"),
+		section("block_id_menu", "Cheese Burger"),
+		slack.NewInputBlock("block_id_steak", plain("Steak"), slack.NewCheckboxGroupsBlockElement("action_id_steak", options...)),
+	)
+	m.Title = plain("Hungryman Hamburgers & Fries")
+	m.PrivateMetadata = strings.Repeat("x", 3001)
+
+	err := blockkit.ValidateView(m)
+	violations, ok := err.(blockkit.Violations)
+	if !ok {
+		t.Fatalf("err = %v, want violations", err)
+	}
+	want := blockkit.Violations{
+		{Path: "title", Message: "28 characters, more than 24"},
+		{Path: "private_metadata", Message: "3001 characters, more than 3000"},
+		{Path: "blocks[1].block_id", Message: `"block_id_menu" is also the block ID of blocks[0]`},
+		{Path: "blocks[2].element.options[1].text", Message: "151 characters, more than 150"},
+	}
+	if !reflect.DeepEqual(violations, want) {
+		t.Errorf("violations = %v, want %v", violations, want)
+	}
+}
+
+func TestTooManyBlocks(t *testing.T) {
+	var blocks []slack.Block
+	for i := 0; i < 101; i++ {
+		blocks = append(blocks, slack.NewDividerBlock())
+	}
+
+	err := blockkit.ValidateView(modal(blocks...))
+	if err == nil || !strings.Contains(err.Error(), "blocks: 101 blocks, more than 100") {
+		t.Errorf("err = %v, want too many blocks", err)
+	}
+
+	// Messages have a lower limit.
+	err = blockkit.ValidateBlocks(blocks[:51])
+	if err == nil || !strings.Contains(err.Error(), "blocks: 51 blocks, more than 50") {
+		t.Errorf("err = %v, want too many blocks", err)
+	}
+	if err := blockkit.ValidateBlocks(blocks[:50]); err != nil {
+		t.Errorf("err = %v, want none", err)
+	}
+}
+
+func TestMessage(t *testing.T) {
+	button := slack.NewButtonBlockElement("action_id_order", "hamburger", plain("Order"))
+	long := slack.NewButtonBlockElement("action_id_order", "ramen", plain(strings.Repeat("o", 76)))
+	actions := slack.NewActionBlock("block_id_shops", button, long)
+
+	err := blockkit.ValidateMessage(slack.MsgOptionBlocks(section("", "What do you want to have?"), actions))
+	want := "blockkit: blocks[1].elements[1].action_id: \"action_id_order\" is also the action ID of blocks[1].elements[0]; " +
+		"blocks[1].elements[1].text: 76 characters, more than 75"
+	if err == nil || err.Error() != want {
+		t.Errorf("err = %v, want %s", err, want)
+	}
+
+	if err := blockkit.ValidateMessage(slack.MsgOptionText("Hello", false)); err != nil {
+		t.Errorf("err = %v, want none for a message without blocks", err)
+	}
+}
+
+func TestStructure(t *testing.T) {
+	m := modal(
+		section("", ""),
+		slack.NewInputBlock("block_id_order", plain(strings.Repeat("l", 2001)), slack.NewButtonBlockElement("action_id_order", "order", plain("Order"))),
+	)
+	m.Title = slack.NewTextBlockObject("mrkdwn", "Shop", false, false)
+
+	err := blockkit.ValidateView(m)
+	want := "blockkit: title.type: \"mrkdwn\" isn't plain_text; blocks[0].text.text: empty; " +
+		"blocks[1].label: 2001 characters, more than 2000; blocks[1].element.type: \"button\" can't be used here"
+	if err == nil || err.Error() != want {
+		t.Errorf("err = %v, want %s", err, want)
+	}
+}
+
+func TestDecodedMessage(t *testing.T) {
+	tests := []struct {
+		message string
+		want    string
+	}{
+		{`{"blocks": [{"type": "divider"}]}`, ""},
+		{`[]`, "blockkit: not an object"},
+		{`{"text": "Hello"}`, "blockkit: blocks: missing; unknown field text"},
+		{`{"blocks": [{"type": "header"}]}`, `blockkit: blocks[0].type: "header" can't be used here`},
+	}
+	for _, tt := range tests {
+		var v interface{}
+		if err := json.Unmarshal([]byte(tt.message), &v); err != nil {
+			t.Fatal(err)
+		}
+		err := blockkit.ValidateDecodedMessage(v)
+		if (tt.want == "" && err != nil) || (tt.want != "" && (err == nil || err.Error() != tt.want)) {
+			t.Errorf("ValidateDecodedMessage(%s) = %v, want %q", tt.message, err, tt.want)
+		}
+	}
+}
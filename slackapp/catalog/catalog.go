@@ -0,0 +1,327 @@
+// Package catalog loads the shops and their menus from a JSON file, so that the shop list message
+// and the order modals are generated from the same data.
+package catalog
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/nicoJN/slack-modal-examples/slackapp/money"
+)
+
+// DefaultPath is where the catalog is loaded from when no path is configured.
+// On Lambda, it's next to the binary in the deployment package.
+const DefaultPath = "catalog.json"
+
+// maxTitleLength is the limit of a modal title, which shows the shop.
+const maxTitleLength = 24
+
+// Inputs of add-on groups.
+const (
+	InputCheckboxes  = "checkboxes"   // Default. Slack shows 10 options at most.
+	InputMultiSelect = "multi_select" // For longer lists, up to 100 options.
+)
+
+// maxOptions is the limit of the options of each input.
+var maxOptions = map[string]int{InputCheckboxes: 10, InputMultiSelect: 100}
+
+// Catalog lists the shops in the order they appear in the shop list.
+type Catalog struct {
+	// Currency is the ISO 4217 code of the prices, unless a shop sets its own.
+	Currency string `json:"currency"`
+
+	Shops []Shop `json:"shops"`
+}
+
+// Shop is a shop users can order from.
+type Shop struct {
+	ID string `json:"id"`
+
+	// ActionID is the action ID of the Order button in the shop list.
+	ActionID string `json:"action_id"`
+
+	Name        string `json:"name"`
+	Title       string `json:"title"` // Shown as the modal title, 24 characters at most.
+	Emoji       string `json:"emoji"`
+	Description string `json:"description"`
+	Greeting    string `json:"greeting"` // Shown at the top of the order modal.
+	Currency    string `json:"currency"` // Defaults to the currency of the catalog.
+
+	Menu []Item `json:"menu"`
+
+	// Questions are asked in the order modal after the menu, e.g. how you like your steak.
+	Questions []Question `json:"questions"`
+
+	// AddOns are offered after the questions, e.g. toppings.
+	AddOns []AddOnGroup `json:"add_ons"`
+}
+
+// Item is an item on the menu of a shop.
+type Item struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Price int64  `json:"price"` // in the minor unit of the currency, e.g. 750 for $ 7.50
+}
+
+// Question is a question with choices, asked with a static select.
+type Question struct {
+	// ID names the input block of the question, so it must be unique in the shop.
+	ID      string   `json:"id"`
+	Label   string   `json:"label"`
+	Choices []Choice `json:"choices"`
+}
+
+// Choice is an answer to a question.
+type Choice struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+
+	// Price is added to the price of the item, e.g. for a larger size, in the minor unit.
+	Price int64 `json:"price,omitempty"`
+}
+
+// AddOnGroup is a group of add-ons, of which users pick as many as they like, or none.
+type AddOnGroup struct {
+	// ID names the input block of the group, so it must be unique among the questions and groups of the shop.
+	ID    string `json:"id"`
+	Label string `json:"label"`
+
+	// Input is InputCheckboxes or InputMultiSelect. Empty means InputCheckboxes.
+	Input string `json:"input"`
+
+	// Options are priced like choices, e.g. extra cheese for $ 1.00 or no onions for free.
+	Options []Choice `json:"options"`
+}
+
+// The block IDs of questions and add-on groups are made from their IDs like the other blocks of the
+// order modal, so their IDs must not be the ID of another input, nor start like the IDs of the menu
+// items (block_id_menu_<item>) and the cart (block_id_cart_<item>, block_id_cart_warning).
+var (
+	reservedQuestionIDs      = map[string]bool{"note": true}
+	reservedQuestionPrefixes = []string{"menu_", "cart_"}
+)
+
+// reservedQuestionID reports whether id would collide with the other blocks of the order modal.
+func reservedQuestionID(id string) bool {
+	if reservedQuestionIDs[id] {
+		return true
+	}
+	for _, p := range reservedQuestionPrefixes {
+		if strings.HasPrefix(id, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// Load reads a catalog file. An empty path means DefaultPath.
+func Load(path string) (*Catalog, error) {
+	if path == "" {
+		path = DefaultPath
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read catalog: %w", err)
+	}
+
+	c, err := Parse(b)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return c, nil
+}
+
+// Parse parses and validates a catalog.
+func Parse(b []byte) (*Catalog, error) {
+	var c Catalog
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, fmt.Errorf("failed to parse catalog: %w", err)
+	}
+	for i := range c.Shops {
+		if c.Shops[i].Currency == "" {
+			c.Shops[i].Currency = c.Currency
+		}
+		for j := range c.Shops[i].AddOns {
+			if c.Shops[i].AddOns[j].Input == "" {
+				c.Shops[i].AddOns[j].Input = InputCheckboxes
+			}
+		}
+	}
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// validate reports every problem at once.
+func (c *Catalog) validate() error {
+	var problems []string
+	add := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	if len(c.Shops) == 0 {
+		add("no shops")
+	}
+
+	shopIDs := map[string]bool{}
+	actionIDs := map[string]bool{}
+	for i, s := range c.Shops {
+		if s.ID == "" {
+			add("shops[%d]: id is empty", i)
+		} else if shopIDs[s.ID] {
+			add("shops[%d]: duplicate id %q", i, s.ID)
+		}
+		shopIDs[s.ID] = true
+
+		if s.ActionID == "" {
+			add("shop %q: action_id is empty", s.ID)
+		} else if actionIDs[s.ActionID] {
+			add("shop %q: duplicate action_id %q", s.ID, s.ActionID)
+		}
+		actionIDs[s.ActionID] = true
+
+		if s.Name == "" {
+			add("shop %q: name is empty", s.ID)
+		}
+		if s.Title == "" || utf8.RuneCountInString(s.Title) > maxTitleLength {
+			add("shop %q: title must have 1 to %d characters", s.ID, maxTitleLength)
+		}
+		if !money.Known(s.Currency) {
+			add("shop %q: unknown currency %q", s.ID, s.Currency)
+		}
+
+		if len(s.Menu) == 0 {
+			add("shop %q: menu is empty", s.ID)
+		}
+		itemIDs := map[string]bool{}
+		for j, item := range s.Menu {
+			if item.ID == "" {
+				add("shop %q: menu[%d]: id is empty", s.ID, j)
+			} else if itemIDs[item.ID] {
+				add("shop %q: duplicate menu id %q", s.ID, item.ID)
+			}
+			itemIDs[item.ID] = true
+
+			if item.Name == "" {
+				add("shop %q: menu item %q: name is empty", s.ID, item.ID)
+			}
+			if item.Price < 0 {
+				add("shop %q: menu item %q: negative price", s.ID, item.ID)
+			}
+		}
+
+		questionIDs := map[string]bool{}
+		for j, q := range s.Questions {
+			if q.ID == "" {
+				add("shop %q: questions[%d]: id is empty", s.ID, j)
+			} else if questionIDs[q.ID] || reservedQuestionID(q.ID) {
+				add("shop %q: duplicate or reserved question id %q", s.ID, q.ID)
+			}
+			questionIDs[q.ID] = true
+
+			if q.Label == "" {
+				add("shop %q: question %q: label is empty", s.ID, q.ID)
+			}
+			if len(q.Choices) == 0 {
+				add("shop %q: question %q: no choices", s.ID, q.ID)
+			}
+			choiceIDs := map[string]bool{}
+			for _, c := range q.Choices {
+				if c.ID == "" || choiceIDs[c.ID] {
+					add("shop %q: question %q: empty or duplicate choice id %q", s.ID, q.ID, c.ID)
+				}
+				choiceIDs[c.ID] = true
+
+				if c.Price < 0 {
+					add("shop %q: choice %q of %q: negative price", s.ID, c.ID, q.ID)
+				}
+			}
+		}
+
+		for j, g := range s.AddOns {
+			if g.ID == "" {
+				add("shop %q: add_ons[%d]: id is empty", s.ID, j)
+			} else if questionIDs[g.ID] || reservedQuestionID(g.ID) {
+				add("shop %q: duplicate or reserved add-on group id %q", s.ID, g.ID)
+			}
+			questionIDs[g.ID] = true
+
+			if g.Label == "" {
+				add("shop %q: add-on group %q: label is empty", s.ID, g.ID)
+			}
+			max, ok := maxOptions[g.Input]
+			if !ok {
+				add("shop %q: add-on group %q: unknown input %q", s.ID, g.ID, g.Input)
+			}
+			if len(g.Options) == 0 || (ok && len(g.Options) > max) {
+				add("shop %q: add-on group %q: must have 1 to %d options", s.ID, g.ID, max)
+			}
+			optionIDs := map[string]bool{}
+			for _, o := range g.Options {
+				if o.ID == "" || optionIDs[o.ID] {
+					add("shop %q: add-on group %q: empty or duplicate option id %q", s.ID, g.ID, o.ID)
+				}
+				optionIDs[o.ID] = true
+
+				if o.Price < 0 {
+					add("shop %q: option %q of %q: negative price", s.ID, o.ID, g.ID)
+				}
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid catalog: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// Shop returns the shop with id.
+func (c *Catalog) Shop(id string) (*Shop, bool) {
+	for i := range c.Shops {
+		if c.Shops[i].ID == id {
+			return &c.Shops[i], true
+		}
+	}
+	return nil, false
+}
+
+// Money returns an amount in the minor unit of the currency of the shop.
+func (s *Shop) Money(amount int64) money.Money {
+	return money.New(amount, s.Currency)
+}
+
+// Item returns the menu item with id.
+func (s *Shop) Item(id string) (*Item, bool) {
+	for i := range s.Menu {
+		if s.Menu[i].ID == id {
+			return &s.Menu[i], true
+		}
+	}
+	return nil, false
+}
+
+// Choice returns the choice with id.
+func (q *Question) Choice(id string) (*Choice, bool) {
+	for i := range q.Choices {
+		if q.Choices[i].ID == id {
+			return &q.Choices[i], true
+		}
+	}
+	return nil, false
+}
+
+// Option returns the option with id.
+func (g *AddOnGroup) Option(id string) (*Choice, bool) {
+	for i := range g.Options {
+		if g.Options[i].ID == id {
+			return &g.Options[i], true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,83 @@
+package catalog_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nicoJN/slack-modal-examples/slackapp/catalog"
+)
+
+// The catalog shipped with the handlers.
+const shippedCatalog = "../../catalog.json"
+
+func TestLoadShippedCatalog(t *testing.T) {
+	c, err := catalog.Load(shippedCatalog)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	shop, ok := c.Shop("hamburger")
+	if !ok {
+		t.Fatal("no hamburger shop")
+	}
+	item, ok := shop.Item("cheese_burger")
+	if !ok || item.Name != "Cheese Burger" {
+		t.Fatalf("cheese_burger = %+v, want Cheese Burger", item)
+	}
+	if got := shop.Money(item.Price).String(); got != "$ 700.00" {
+		t.Errorf("price of cheese_burger = %s, want $ 700.00", got)
+	}
+	if q := shop.Questions[0]; q.ID != "steak" {
+		t.Errorf("first question = %q, want steak", q.ID)
+	} else if ch, ok := q.Choice("medium"); !ok || ch.Name != "medium" {
+		t.Errorf("medium = %+v, want medium", ch)
+	}
+	if g := shop.AddOns[0]; g.Input != catalog.InputCheckboxes {
+		t.Errorf("input of %s = %q, want the default %q", g.ID, g.Input, catalog.InputCheckboxes)
+	} else if o, ok := g.Option("bacon"); !ok || o.Price == 0 {
+		t.Errorf("bacon = %+v, want a priced option", o)
+	}
+	if _, ok := c.Shop("pizza"); ok {
+		t.Error("found an unknown shop")
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want string
+	}{
+		{"no shops", `{"shops": []}`, "no shops"},
+		{"long title", `{"shops": [{"id": "a", "action_id": "a", "name": "A", "title": "A title which is far too long", "menu": [{"id": "x", "name": "X"}]}]}`, "title"},
+		{"duplicate shop", `{"shops": [
+			{"id": "a", "action_id": "a", "name": "A", "title": "A", "menu": [{"id": "x", "name": "X"}]},
+			{"id": "a", "action_id": "b", "name": "B", "title": "B", "menu": [{"id": "x", "name": "X"}]}]}`, "duplicate id"},
+		{"empty menu", `{"shops": [{"id": "a", "action_id": "a", "name": "A", "title": "A"}]}`, "menu is empty"},
+		{"duplicate item", `{"shops": [{"id": "a", "action_id": "a", "name": "A", "title": "A", "menu": [{"id": "x", "name": "X"}, {"id": "x", "name": "Y"}]}]}`, "duplicate menu id"},
+		{"reserved question", `{"shops": [{"id": "a", "action_id": "a", "name": "A", "title": "A", "menu": [{"id": "x", "name": "X"}],
+			"questions": [{"id": "note", "label": "Note", "choices": [{"id": "y", "name": "Y"}]}]}]}`, "reserved question id"},
+		{"menu prefix", `{"shops": [{"id": "a", "action_id": "a", "name": "A", "title": "A", "menu": [{"id": "x", "name": "X"}],
+			"questions": [{"id": "menu_x", "label": "X", "choices": [{"id": "y", "name": "Y"}]}]}]}`, "reserved question id"},
+		{"cart prefix", `{"shops": [{"id": "a", "action_id": "a", "name": "A", "title": "A", "menu": [{"id": "x", "name": "X"}],
+			"add_ons": [{"id": "cart_warning", "label": "Warning", "options": [{"id": "z", "name": "Z"}]}]}]}`, "reserved add-on group id"},
+		{"no choices", `{"shops": [{"id": "a", "action_id": "a", "name": "A", "title": "A", "menu": [{"id": "x", "name": "X"}],
+			"questions": [{"id": "size", "label": "Size"}]}]}`, "no choices"},
+		{"question and add-ons", `{"shops": [{"id": "a", "action_id": "a", "name": "A", "title": "A", "menu": [{"id": "x", "name": "X"}],
+			"questions": [{"id": "size", "label": "Size", "choices": [{"id": "y", "name": "Y"}]}],
+			"add_ons": [{"id": "size", "label": "Size", "options": [{"id": "z", "name": "Z"}]}]}]}`, "duplicate or reserved add-on group id"},
+		{"unknown input", `{"shops": [{"id": "a", "action_id": "a", "name": "A", "title": "A", "menu": [{"id": "x", "name": "X"}],
+			"add_ons": [{"id": "toppings", "label": "Toppings", "input": "radio", "options": [{"id": "z", "name": "Z"}]}]}]}`, "unknown input"},
+		{"negative price", `{"currency": "USD", "shops": [{"id": "a", "action_id": "a", "name": "A", "title": "A", "menu": [{"id": "x", "name": "X", "price": -1}]}]}`, "negative price"},
+		{"unknown currency", `{"currency": "XXX", "shops": [{"id": "a", "action_id": "a", "name": "A", "title": "A", "menu": [{"id": "x", "name": "X"}]}]}`, "unknown currency"},
+		{"broken", `{`, "failed to parse"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := catalog.Parse([]byte(tt.json))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want an error about %q", err, tt.want)
+			}
+		})
+	}
+}
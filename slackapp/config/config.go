@@ -0,0 +1,132 @@
+// Package config resolves the settings of the Slack handlers (signing secret, bot token, ...)
+// from environment variables, a local JSON file or a secrets store.
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Keys of the settings.
+const (
+	KeySigningSecret = "SLACK_SIGNING_SECRET"
+	KeyBotToken      = "SLACK_BOT_TOKEN"
+	KeyAppToken      = "SLACK_APP_TOKEN"
+	KeyLogLevel      = "LOG_LEVEL"
+	KeyEventIDFile   = "EVENT_ID_FILE"
+	KeyCatalogFile   = "CATALOG_FILE"
+	KeyTemplateDir   = "TEMPLATE_DIR"
+	KeySessionFile   = "SESSION_FILE"
+	KeySessionDB     = "SESSION_DB"
+	KeySessionTable  = "SESSION_TABLE"
+
+	// KeyConfigFile and KeySecretsDir are read from environment variables only.
+	// They tell LoadDefault where the other settings live.
+	KeyConfigFile = "CONFIG_FILE"
+	KeySecretsDir = "SECRETS_DIR"
+)
+
+// Config holds the resolved settings.
+type Config struct {
+	SigningSecret string
+	BotToken      string
+
+	// AppToken is an app-level token (xapp-...) which is only needed in Socket Mode.
+	AppToken string
+
+	// LogLevel is one of debug, info, warn and error. Empty means info.
+	LogLevel string
+
+	// EventIDFile is a file which records handled event IDs to skip Events API retries.
+	// Empty means they're kept in memory.
+	EventIDFile string
+
+	// CatalogFile is the JSON file which lists the shops. Empty means catalog.DefaultPath.
+	CatalogFile string
+
+	// TemplateDir is the directory of the modal and message templates.
+	// Empty means the modals and messages are built in Go.
+	TemplateDir string
+
+	// SessionFile is a file which keeps the orders in progress. Empty means they're kept in memory.
+	SessionFile string
+
+	// SessionDB is a SQLite database which keeps the orders in progress, and which processes on one host
+	// or on a shared file system can share. It takes precedence over SessionFile.
+	SessionDB string
+
+	// SessionTable is a DynamoDB table which keeps the orders in progress, and which every Lambda
+	// container shares. It takes precedence over SessionDB and SessionFile.
+	SessionTable string
+}
+
+// field describes a setting and where its resolved value is stored.
+type field struct {
+	key      string
+	required bool
+	dst      *string
+}
+
+func (c *Config) fields() []field {
+	return []field{
+		{key: KeySigningSecret, required: true, dst: &c.SigningSecret},
+		{key: KeyBotToken, required: true, dst: &c.BotToken},
+		{key: KeyAppToken, required: false, dst: &c.AppToken},
+		{key: KeyLogLevel, required: false, dst: &c.LogLevel},
+		{key: KeyEventIDFile, required: false, dst: &c.EventIDFile},
+		{key: KeyCatalogFile, required: false, dst: &c.CatalogFile},
+		{key: KeyTemplateDir, required: false, dst: &c.TemplateDir},
+		{key: KeySessionFile, required: false, dst: &c.SessionFile},
+		{key: KeySessionDB, required: false, dst: &c.SessionDB},
+		{key: KeySessionTable, required: false, dst: &c.SessionTable},
+	}
+}
+
+// Load resolves every setting from a provider and validates the result.
+// All missing settings are reported at once.
+func Load(p Provider) (*Config, error) {
+	var c Config
+	var missing []string
+
+	for _, f := range c.fields() {
+		v, ok, err := p.Lookup(f.key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up %s in %s: %w", f.key, p.Name(), err)
+		}
+		if !ok || v == "" {
+			if f.required {
+				missing = append(missing, f.key)
+			}
+			continue
+		}
+		*f.dst = v
+	}
+
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("missing required settings %s (looked in %s)", strings.Join(missing, ", "), p.Name())
+	}
+
+	return &c, nil
+}
+
+// LoadDefault resolves the settings from the providers configured by environment variables.
+// Environment variables always take precedence. A JSON file is consulted when CONFIG_FILE is set,
+// and a file-backed secrets store is consulted when SECRETS_DIR is set.
+func LoadDefault() (*Config, error) {
+	providers := []Provider{NewEnvProvider()}
+
+	if path := os.Getenv(KeyConfigFile); path != "" {
+		p, err := NewFileProvider(path)
+		if err != nil {
+			return nil, err
+		}
+		providers = append(providers, p)
+	}
+
+	if dir := os.Getenv(KeySecretsDir); dir != "" {
+		providers = append(providers, NewSecretStoreProvider(NewFileSecretStore(dir)))
+	}
+
+	return Load(Chain(providers...))
+}
@@ -0,0 +1,160 @@
+package config_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/nicoJN/slack-modal-examples/slackapp/config"
+)
+
+// values is a provider backed by a map.
+type values struct {
+	name string
+	m    map[string]string
+	err  error
+}
+
+func (v values) Name() string { return v.name }
+
+func (v values) Lookup(key string) (string, bool, error) {
+	if v.err != nil {
+		return "", false, v.err
+	}
+	s, ok := v.m[key]
+	return s, ok, nil
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  map[string]string
+		want    *config.Config
+		wantErr string
+	}{
+		{
+			name:   "required",
+			values: map[string]string{config.KeySigningSecret: "secret", config.KeyBotToken: "xoxb-1"},
+			want:   &config.Config{SigningSecret: "secret", BotToken: "xoxb-1"},
+		},
+		{
+			name: "optional",
+			values: map[string]string{
+				config.KeySigningSecret: "secret",
+				config.KeyBotToken:      "xoxb-1",
+				config.KeyLogLevel:      "debug",
+				config.KeySessionFile:   "sessions.json",
+				config.KeySessionDB:     "sessions.db",
+				config.KeySessionTable:  "sessions",
+			},
+			want: &config.Config{SigningSecret: "secret", BotToken: "xoxb-1", LogLevel: "debug", SessionFile: "sessions.json", SessionDB: "sessions.db", SessionTable: "sessions"},
+		},
+		{
+			name:    "every missing key at once",
+			values:  map[string]string{config.KeyLogLevel: "debug"},
+			wantErr: "missing required settings SLACK_SIGNING_SECRET, SLACK_BOT_TOKEN (looked in test)",
+		},
+		{
+			name:    "empty value",
+			values:  map[string]string{config.KeySigningSecret: "secret", config.KeyBotToken: ""},
+			wantErr: "missing required settings SLACK_BOT_TOKEN (looked in test)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := config.Load(values{name: "test", m: tt.values})
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("err = %v, want %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("config = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadProviderError(t *testing.T) {
+	_, err := config.Load(values{name: "test", err: errors.New("access denied")})
+	if err == nil || !strings.Contains(err.Error(), "failed to look up SLACK_SIGNING_SECRET in test: access denied") {
+		t.Errorf("err = %v, want the failed lookup", err)
+	}
+}
+
+func TestChain(t *testing.T) {
+	first := values{name: "first", m: map[string]string{"A": "first", "B": ""}}
+	second := values{name: "second", m: map[string]string{"A": "second", "B": "second", "C": "second"}}
+	c := config.Chain(first, second)
+
+	if got := c.Name(); got != "first, second" {
+		t.Errorf("name = %q, want first, second", got)
+	}
+
+	tests := []struct {
+		key    string
+		want   string
+		wantOK bool
+	}{
+		{"A", "first", true},  // The first provider takes precedence.
+		{"B", "second", true}, // An empty value falls through.
+		{"C", "second", true}, // So does a missing one.
+		{"D", "", false},      // No provider knows it.
+	}
+	for _, tt := range tests {
+		got, ok, err := c.Lookup(tt.key)
+		if err != nil || got != tt.want || ok != tt.wantOK {
+			t.Errorf("Lookup(%s) = (%q, %v, %v), want (%q, %v, nil)", tt.key, got, ok, err, tt.want, tt.wantOK)
+		}
+	}
+
+	broken := config.Chain(values{name: "broken", err: errors.New("access denied")}, second)
+	if _, _, err := broken.Lookup("A"); err == nil || err.Error() != "broken: access denied" {
+		t.Errorf("err = %v, want the error of the broken provider", err)
+	}
+}
+
+func TestFileSecretStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "secrets")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"LF":    "secret\n",
+		"CRLF":  "secret\r\n",
+		"none":  "secret",
+		"inner": "sec\nret\n",
+	}
+	for name, content := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s := config.NewFileSecretStore(dir)
+	for name, want := range map[string]string{"LF": "secret", "CRLF": "secret", "none": "secret", "inner": "sec\nret"} {
+		if got, err := s.GetSecret(name); err != nil || got != want {
+			t.Errorf("GetSecret(%s) = (%q, %v), want %q", name, got, err, want)
+		}
+	}
+
+	if _, err := s.GetSecret("missing"); !errors.Is(err, config.ErrSecretNotFound) {
+		t.Errorf("err = %v, want ErrSecretNotFound", err)
+	}
+
+	// A missing secret is unknown to the provider, so that a chain moves on.
+	v, ok, err := config.NewSecretStoreProvider(s).Lookup("missing")
+	if v != "" || ok || err != nil {
+		t.Errorf("Lookup(missing) = (%q, %v, %v), want not found", v, ok, err)
+	}
+}
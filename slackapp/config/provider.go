@@ -0,0 +1,156 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Provider looks up a setting by its key.
+// ok is false when the provider doesn't know the key.
+type Provider interface {
+	Name() string
+	Lookup(key string) (value string, ok bool, err error)
+}
+
+// chain asks each provider in order and returns the first value found.
+type chain []Provider
+
+// Chain returns a provider that consults the given providers in order.
+func Chain(providers ...Provider) Provider {
+	return chain(providers)
+}
+
+func (c chain) Name() string {
+	names := make([]string, 0, len(c))
+	for _, p := range c {
+		names = append(names, p.Name())
+	}
+	return strings.Join(names, ", ")
+}
+
+func (c chain) Lookup(key string) (string, bool, error) {
+	for _, p := range c {
+		v, ok, err := p.Lookup(key)
+		if err != nil {
+			return "", false, fmt.Errorf("%s: %w", p.Name(), err)
+		}
+		if ok && v != "" {
+			return v, true, nil
+		}
+	}
+	return "", false, nil
+}
+
+// envProvider reads settings from environment variables.
+type envProvider struct{}
+
+// NewEnvProvider returns a provider backed by environment variables.
+func NewEnvProvider() Provider {
+	return envProvider{}
+}
+
+func (envProvider) Name() string {
+	return "environment variables"
+}
+
+func (envProvider) Lookup(key string) (string, bool, error) {
+	v, ok := os.LookupEnv(key)
+	return v, ok, nil
+}
+
+// fileProvider reads settings from a flat JSON object like {"SLACK_BOT_TOKEN": "xoxb-..."}.
+type fileProvider struct {
+	path   string
+	values map[string]string
+}
+
+// NewFileProvider reads a JSON file and returns a provider backed by it.
+func NewFileProvider(path string) (Provider, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var values map[string]string
+	if err := json.Unmarshal(b, &values); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config file %s: %w", path, err)
+	}
+
+	return &fileProvider{path: path, values: values}, nil
+}
+
+func (p *fileProvider) Name() string {
+	return "file " + p.path
+}
+
+func (p *fileProvider) Lookup(key string) (string, bool, error) {
+	v, ok := p.values[key]
+	return v, ok, nil
+}
+
+// ErrSecretNotFound is returned by a SecretStore when the secret doesn't exist.
+var ErrSecretNotFound = errors.New("secret not found")
+
+// SecretStore is the interface of a secrets store such as AWS Secrets Manager.
+type SecretStore interface {
+	Name() string
+	GetSecret(name string) (string, error)
+}
+
+// secretStoreProvider adapts a SecretStore to a Provider.
+type secretStoreProvider struct {
+	store SecretStore
+}
+
+// NewSecretStoreProvider returns a provider backed by a secrets store.
+func NewSecretStoreProvider(store SecretStore) Provider {
+	return &secretStoreProvider{store: store}
+}
+
+func (p *secretStoreProvider) Name() string {
+	return p.store.Name()
+}
+
+func (p *secretStoreProvider) Lookup(key string) (string, bool, error) {
+	v, err := p.store.GetSecret(key)
+	if errors.Is(err, ErrSecretNotFound) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return v, true, nil
+}
+
+// FileSecretStore is a stand-in for a real secrets store.
+// Each secret is stored in its own file named after the key, like Docker or Kubernetes secrets.
+type FileSecretStore struct {
+	dir string
+}
+
+// NewFileSecretStore returns a secrets store that reads files in dir.
+func NewFileSecretStore(dir string) *FileSecretStore {
+	return &FileSecretStore{dir: dir}
+}
+
+// Name returns the name of the store.
+func (s *FileSecretStore) Name() string {
+	return "secrets dir " + s.dir
+}
+
+// GetSecret returns the content of the file named after the secret, without trailing newlines.
+func (s *FileSecretStore) GetSecret(name string) (string, error) {
+	b, err := ioutil.ReadFile(filepath.Join(s.dir, name))
+	if os.IsNotExist(err) {
+		return "", ErrSecretNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read secret %s: %w", name, err)
+	}
+	return strings.TrimRight(string(b), "\r\n"), nil
+}
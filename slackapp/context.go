@@ -0,0 +1,60 @@
+package slackapp
+
+import (
+	"context"
+
+	"github.com/nicoJN/slack-modal-examples/slackapp/logging"
+	"github.com/slack-go/slack"
+)
+
+type contextKey int
+
+const (
+	skipVerificationKey contextKey = iota
+	requestIDKey
+)
+
+// RequestID returns the ID of the request set by RequestLogging.
+// It's shown to users as a reference when their request fails.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
+
+// WithRequestID returns a context which carries the request ID, e.g. to continue a request in a
+// background job.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey, id)
+}
+
+// SkipVerification marks a request which carries no signature because its transport is
+// authenticated otherwise, e.g. Socket Mode. VerifySignature lets such requests through.
+func SkipVerification(ctx context.Context) context.Context {
+	return context.WithValue(ctx, skipVerificationKey, true)
+}
+
+func verificationSkipped(ctx context.Context) bool {
+	skip, _ := ctx.Value(skipVerificationKey).(bool)
+	return skip
+}
+
+// TagInteraction returns a context whose logger is tagged with the IDs of an interaction.
+func TagInteraction(ctx context.Context, message slack.InteractionCallback) context.Context {
+	callbackID := message.View.CallbackID
+	if callbackID == "" {
+		callbackID = message.CallbackID
+	}
+
+	channelID := message.Channel.ID
+	if channelID == "" {
+		channelID = message.Container.ChannelID
+	}
+
+	return logging.With(ctx,
+		logging.KeyTeamID, message.Team.ID,
+		logging.KeyUserID, message.User.ID,
+		logging.KeyChannelID, channelID,
+		logging.KeyCallbackID, callbackID,
+		logging.KeyTriggerID, message.TriggerID,
+	)
+}
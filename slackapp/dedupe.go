@@ -0,0 +1,81 @@
+package slackapp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/nicoJN/slack-modal-examples/slackapp/idempotency"
+	"github.com/nicoJN/slack-modal-examples/slackapp/logging"
+)
+
+// Headers of Events API retries.
+// See https://api.slack.com/apis/connections/events-api#retries
+const (
+	HeaderRetryNum    = "X-Slack-Retry-Num"
+	HeaderRetryReason = "X-Slack-Retry-Reason"
+	HeaderNoRetry     = "X-Slack-No-Retry"
+)
+
+// EventTTL is how long an event ID is remembered. Slack retries an event 3 times over about
+// 5 minutes, so this covers them with a margin.
+const EventTTL = time.Hour
+
+// DeduplicateEvents passes each Events API event to the handler once, keyed by its event_id.
+// A redelivered event is acknowledged without being handled, and told not to be retried again.
+// If the handler fails, the event ID is released so that a retry can handle it.
+func DeduplicateEvents(store idempotency.Store, ttl time.Duration) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+			var envelope struct {
+				EventID string `json:"event_id"`
+			}
+			if err := json.Unmarshal([]byte(request.Body), &envelope); err != nil || envelope.EventID == "" {
+				// e.g. URL verification, which has no event ID.
+				return next(ctx, request)
+			}
+
+			ctx = logging.With(ctx, logging.KeyEventID, envelope.EventID)
+			if num := header(request, HeaderRetryNum); num != "" {
+				logging.FromContext(ctx).Info("Received a retried event", "retry_num", num, "retry_reason", header(request, HeaderRetryReason))
+			}
+
+			claimed, err := store.Claim(ctx, envelope.EventID, ttl)
+			if err != nil {
+				// Handling twice is better than not at all.
+				logging.FromContext(ctx).Error("Failed to claim event", logging.KeyError, err)
+				return next(ctx, request)
+			}
+			if !claimed {
+				logging.FromContext(ctx).Info("Skipped a duplicate event")
+				res := OK()
+				res.Headers = map[string]string{HeaderNoRetry: "1"}
+				return res, nil
+			}
+
+			res, err := next(ctx, request)
+			if err != nil || res.StatusCode >= http.StatusInternalServerError {
+				if err := store.Release(ctx, envelope.EventID); err != nil {
+					logging.FromContext(ctx).Error("Failed to release event", logging.KeyError, err)
+				}
+			}
+			return res, err
+		}
+	}
+}
+
+// header returns a request header regardless of the case of its name.
+func header(request events.APIGatewayProxyRequest, name string) string {
+	if v, ok := request.Headers[name]; ok {
+		return v
+	}
+	for k, v := range request.Headers {
+		if strings.EqualFold(k, name) {
+			return v
+		}
+	}
+	return ""
+}
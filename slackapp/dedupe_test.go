@@ -0,0 +1,86 @@
+package slackapp_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/nicoJN/slack-modal-examples/slackapp"
+	"github.com/nicoJN/slack-modal-examples/slackapp/idempotency"
+	"github.com/nicoJN/slack-modal-examples/slackapp/slacktest"
+)
+
+func TestDeduplicateEvents(t *testing.T) {
+	calls := 0
+	h := slackapp.Chain(func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		calls++
+		return slackapp.OK(), nil
+	}, slackapp.DeduplicateEvents(idempotency.NewMemoryStore(), slackapp.EventTTL))
+
+	request, err := slacktest.NewEventRequest(slacktest.SigningSecret, slacktest.AppMention("C0001", "U0001", "<@B0001>"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	retry := request
+	retry.Headers = map[string]string{"x-slack-retry-num": "1", "x-slack-retry-reason": "http_timeout"}
+	for k, v := range request.Headers {
+		retry.Headers[k] = v
+	}
+
+	// The first delivery is handled.
+	h(context.Background(), request)
+	if calls != 1 {
+		t.Fatalf("calls = %d, want 1", calls)
+	}
+
+	// The retry is acknowledged without being handled.
+	res, err := h(context.Background(), retry)
+	if err != nil || res.StatusCode != 200 {
+		t.Fatalf("got (%d, %v), want 200 without error", res.StatusCode, err)
+	}
+	if calls != 1 {
+		t.Errorf("calls = %d, want 1", calls)
+	}
+	if res.Headers[slackapp.HeaderNoRetry] != "1" {
+		t.Errorf("headers = %v, want %s", res.Headers, slackapp.HeaderNoRetry)
+	}
+}
+
+func TestDeduplicateEventsAfterFailure(t *testing.T) {
+	calls := 0
+	h := slackapp.Chain(func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		calls++
+		if calls == 1 {
+			return events.APIGatewayProxyResponse{StatusCode: 500}, nil
+		}
+		return slackapp.OK(), nil
+	}, slackapp.DeduplicateEvents(idempotency.NewMemoryStore(), slackapp.EventTTL))
+
+	request, err := slacktest.NewEventRequest(slacktest.SigningSecret, slacktest.AppMention("C0001", "U0001", "<@B0001>"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A failed event is handled again on retry.
+	h(context.Background(), request)
+	h(context.Background(), request)
+	if calls != 2 {
+		t.Errorf("calls = %d, want 2", calls)
+	}
+}
+
+func TestDeduplicateEventsWithoutID(t *testing.T) {
+	calls := 0
+	h := slackapp.Chain(func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		calls++
+		return slackapp.OK(), nil
+	}, slackapp.DeduplicateEvents(idempotency.NewMemoryStore(), slackapp.EventTTL))
+
+	// URL verification has no event ID and is always handled.
+	request := slacktest.NewRequest(slacktest.SigningSecret, `{"type":"url_verification","challenge":"abc"}`)
+	h(context.Background(), request)
+	h(context.Background(), request)
+	if calls != 2 {
+		t.Errorf("calls = %d, want 2", calls)
+	}
+}
@@ -0,0 +1,12 @@
+module github.com/nicoJN/slack-modal-examples/slackapp
+
+go 1.14
+
+require (
+	github.com/aws/aws-lambda-go v1.19.1
+	github.com/aws/aws-sdk-go v1.46.7
+	github.com/gorilla/websocket v1.4.2
+	github.com/pkg/errors v0.9.1 // indirect
+	github.com/slack-go/slack v0.6.6
+	modernc.org/sqlite v1.10.6
+)
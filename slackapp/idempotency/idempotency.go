@@ -0,0 +1,123 @@
+// Package idempotency remembers which requests have been handled, so that a redelivered request
+// (e.g. an Events API retry) isn't handled twice.
+package idempotency
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/nicoJN/slack-modal-examples/slackapp/internal/jsonfile"
+)
+
+// Store records keys for a while.
+type Store interface {
+	// Claim records key until ttl passes. claimed is false if key has already been recorded.
+	Claim(ctx context.Context, key string, ttl time.Duration) (claimed bool, err error)
+
+	// Release forgets key, e.g. to let a retry handle a request which failed.
+	Release(ctx context.Context, key string) error
+}
+
+// expiries maps keys to the time they expire.
+type expiries map[string]time.Time
+
+// claim records key unless it's recorded and not expired yet. Expired keys are dropped.
+func (e expiries) claim(key string, ttl time.Duration, now time.Time) bool {
+	for k, t := range e {
+		if !now.Before(t) {
+			delete(e, k)
+		}
+	}
+
+	if _, ok := e[key]; ok {
+		return false
+	}
+	e[key] = now.Add(ttl)
+	return true
+}
+
+// MemoryStore keeps keys in memory. It's shared only by the requests to one process, e.g. one warm
+// Lambda container.
+type MemoryStore struct {
+	mu   sync.Mutex
+	keys expiries
+}
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{keys: expiries{}}
+}
+
+// Claim implements Store.
+func (s *MemoryStore) Claim(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.keys.claim(key, ttl, time.Now()), nil
+}
+
+// Release implements Store.
+func (s *MemoryStore) Release(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.keys, key)
+	return nil
+}
+
+// FileStore keeps keys in a JSON file, so that they survive restarts of the process.
+// The file must not be shared by multiple processes.
+type FileStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileStore returns a FileStore backed by path. The file is created on the first claim.
+func NewFileStore(path string) *FileStore {
+	return &FileStore{path: path}
+}
+
+// Claim implements Store.
+func (s *FileStore) Claim(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	keys, err := s.load()
+	if err != nil {
+		return false, err
+	}
+	if !keys.claim(key, ttl, time.Now()) {
+		return false, nil
+	}
+	return true, s.save(keys)
+}
+
+// Release implements Store.
+func (s *FileStore) Release(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	keys, err := s.load()
+	if err != nil {
+		return err
+	}
+	delete(keys, key)
+	return s.save(keys)
+}
+
+func (s *FileStore) load() (expiries, error) {
+	keys := expiries{}
+	if err := jsonfile.Read(s.path, &keys); err != nil {
+		return nil, fmt.Errorf("failed to read idempotency file: %w", err)
+	}
+	return keys, nil
+}
+
+func (s *FileStore) save(keys expiries) error {
+	if err := jsonfile.Write(s.path, keys); err != nil {
+		return fmt.Errorf("failed to write idempotency file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package idempotency_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nicoJN/slack-modal-examples/slackapp/idempotency"
+)
+
+func TestStores(t *testing.T) {
+	dir, err := ioutil.TempDir("", "idempotency")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	stores := map[string]func() idempotency.Store{
+		"memory": func() idempotency.Store { return idempotency.NewMemoryStore() },
+		"file":   func() idempotency.Store { return idempotency.NewFileStore(filepath.Join(dir, "keys.json")) },
+	}
+	for name, newStore := range stores {
+		t.Run(name, func(t *testing.T) {
+			s := newStore()
+			ctx := context.Background()
+
+			claim := func(key string, ttl time.Duration, want bool) {
+				t.Helper()
+				got, err := s.Claim(ctx, key, ttl)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got != want {
+					t.Errorf("Claim(%s) = %v, want %v", key, got, want)
+				}
+			}
+
+			claim("Ev0001", time.Hour, true)
+			claim("Ev0001", time.Hour, false)
+			claim("Ev0002", time.Hour, true)
+
+			// A released key can be claimed again.
+			if err := s.Release(ctx, "Ev0001"); err != nil {
+				t.Fatal(err)
+			}
+			claim("Ev0001", time.Hour, true)
+
+			// An expired key can be claimed again.
+			claim("Ev0003", 10*time.Millisecond, true)
+			time.Sleep(20 * time.Millisecond)
+			claim("Ev0003", time.Hour, true)
+		})
+	}
+}
+
+func TestFileStorePersists(t *testing.T) {
+	dir, err := ioutil.TempDir("", "idempotency")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "keys.json")
+
+	if _, err := idempotency.NewFileStore(path).Claim(context.Background(), "Ev0001", time.Hour); err != nil {
+		t.Fatal(err)
+	}
+
+	// Another store, e.g. after a restart, sees the key.
+	claimed, err := idempotency.NewFileStore(path).Claim(context.Background(), "Ev0001", time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claimed {
+		t.Error("claimed a key recorded before the restart")
+	}
+}
@@ -0,0 +1,47 @@
+package jsonfile_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/nicoJN/slack-modal-examples/slackapp/internal/jsonfile"
+)
+
+func TestReadWrite(t *testing.T) {
+	dir, err := ioutil.TempDir("", "jsonfile")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "state.json")
+
+	// A missing file leaves the value as it is.
+	got := map[string]int{"a": 1}
+	if err := jsonfile.Read(path, &got); err != nil || !reflect.DeepEqual(got, map[string]int{"a": 1}) {
+		t.Errorf("Read = (%v, %v), want the value as it was", got, err)
+	}
+
+	if err := jsonfile.Write(path, map[string]int{"b": 2}); err != nil {
+		t.Fatal(err)
+	}
+	got = map[string]int{}
+	if err := jsonfile.Read(path, &got); err != nil || !reflect.DeepEqual(got, map[string]int{"b": 2}) {
+		t.Errorf("Read = (%v, %v), want map[b:2]", got, err)
+	}
+
+	// Only the file is left behind, without a temporary file.
+	if files, _ := ioutil.ReadDir(dir); len(files) != 1 {
+		t.Errorf("files = %d, want 1", len(files))
+	}
+
+	if err := ioutil.WriteFile(path, []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := jsonfile.Read(path, &got); err == nil || !strings.Contains(err.Error(), "failed to parse") {
+		t.Errorf("err = %v, want a parse error", err)
+	}
+}
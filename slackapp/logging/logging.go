@@ -0,0 +1,250 @@
+// Package logging writes leveled, structured logs as JSON lines.
+// A logger carries fields like the request ID and the Slack user ID, and is passed to handlers
+// through the context so that every line of a request can be correlated.
+package logging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Level is the severity of a log line.
+type Level int
+
+// Levels.
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+var levelNames = map[Level]string{
+	LevelDebug: "debug",
+	LevelInfo:  "info",
+	LevelWarn:  "warn",
+	LevelError: "error",
+}
+
+func (l Level) String() string {
+	if name, ok := levelNames[l]; ok {
+		return name
+	}
+	return fmt.Sprintf("level(%d)", int(l))
+}
+
+// ParseLevel parses a level name like "info". An empty string means LevelInfo.
+func ParseLevel(s string) (Level, error) {
+	if s == "" {
+		return LevelInfo, nil
+	}
+	for l, name := range levelNames {
+		if strings.EqualFold(s, name) {
+			return l, nil
+		}
+	}
+	return LevelInfo, fmt.Errorf("unknown log level: %q", s)
+}
+
+// Keys of the fields which correlate log lines.
+const (
+	KeyRequestID  = "request_id"
+	KeyTeamID     = "team_id"
+	KeyUserID     = "user_id"
+	KeyChannelID  = "channel_id"
+	KeyCallbackID = "callback_id"
+	KeyTriggerID  = "trigger_id"
+	KeyEventID    = "event_id"
+	KeyError      = "error"
+)
+
+type field struct {
+	key   string
+	value interface{}
+}
+
+// output is shared by a logger and the loggers derived from it.
+type output struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// Logger writes JSON lines with its fields.
+type Logger struct {
+	out    *output
+	level  Level
+	fields []field
+	now    func() time.Time
+}
+
+// New returns a Logger which writes lines at level or above to w.
+func New(w io.Writer, level Level) *Logger {
+	return &Logger{
+		out:   &output{w: w},
+		level: level,
+		now:   time.Now,
+	}
+}
+
+// With returns a Logger with additional fields given as key-value pairs.
+// Fields with empty values are omitted, and a later field overrides an earlier one with the same key.
+func (l *Logger) With(kv ...interface{}) *Logger {
+	child := *l
+	child.fields = append(append([]field(nil), l.fields...), pairs(kv)...)
+	return &child
+}
+
+// Debug writes a line at LevelDebug.
+func (l *Logger) Debug(msg string, kv ...interface{}) {
+	l.log(LevelDebug, msg, kv)
+}
+
+// Info writes a line at LevelInfo.
+func (l *Logger) Info(msg string, kv ...interface{}) {
+	l.log(LevelInfo, msg, kv)
+}
+
+// Warn writes a line at LevelWarn.
+func (l *Logger) Warn(msg string, kv ...interface{}) {
+	l.log(LevelWarn, msg, kv)
+}
+
+// Error writes a line at LevelError.
+func (l *Logger) Error(msg string, kv ...interface{}) {
+	l.log(LevelError, msg, kv)
+}
+
+// Fatal writes a line at LevelError and exits.
+func (l *Logger) Fatal(msg string, kv ...interface{}) {
+	l.log(LevelError, msg, kv)
+	os.Exit(1)
+}
+
+func (l *Logger) log(level Level, msg string, kv []interface{}) {
+	if level < l.level {
+		return
+	}
+
+	// Keep the order of the fields, while letting later fields override earlier ones.
+	fields := append(append([]field(nil), l.fields...), pairs(kv)...)
+	index := map[string]int{}
+	var keys []string
+	values := map[string]interface{}{}
+	for _, f := range fields {
+		if _, ok := index[f.key]; !ok {
+			index[f.key] = len(keys)
+			keys = append(keys, f.key)
+		}
+		values[f.key] = f.value
+	}
+
+	var b strings.Builder
+	b.WriteString("{")
+	writeField(&b, "time", l.now().UTC().Format(time.RFC3339Nano))
+	b.WriteString(",")
+	writeField(&b, "level", level.String())
+	b.WriteString(",")
+	writeField(&b, "msg", msg)
+	for _, k := range keys {
+		if k == "time" || k == "level" || k == "msg" {
+			continue
+		}
+		b.WriteString(",")
+		writeField(&b, k, values[k])
+	}
+	b.WriteString("}\n")
+
+	l.out.mu.Lock()
+	defer l.out.mu.Unlock()
+	io.WriteString(l.out.w, b.String())
+}
+
+func writeField(b *strings.Builder, key string, value interface{}) {
+	k, _ := json.Marshal(key)
+	b.Write(k)
+	b.WriteString(":")
+
+	switch v := value.(type) {
+	case error:
+		value = v.Error()
+	case fmt.Stringer:
+		value = v.String()
+	}
+
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		bytes, _ = json.Marshal(fmt.Sprint(value))
+	}
+	b.Write(bytes)
+}
+
+// pairs converts key-value pairs into fields, skipping empty values.
+func pairs(kv []interface{}) []field {
+	var fields []field
+	for i := 0; i < len(kv); i += 2 {
+		key := fmt.Sprint(kv[i])
+		if i+1 == len(kv) {
+			fields = append(fields, field{key: "!BADKEY", value: key})
+			break
+		}
+		if v := kv[i+1]; v != nil && v != "" {
+			fields = append(fields, field{key: key, value: v})
+		}
+	}
+	return fields
+}
+
+var (
+	defaultMu     sync.RWMutex
+	defaultLogger = New(os.Stdout, LevelInfo)
+)
+
+// Default returns the logger used when the context has none.
+func Default() *Logger {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultLogger
+}
+
+// SetDefault replaces the logger used when the context has none.
+func SetDefault(l *Logger) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	defaultLogger = l
+}
+
+// Configure replaces the default logger with one writing to stdout at the named level.
+func Configure(level string) error {
+	l, err := ParseLevel(level)
+	if err != nil {
+		return err
+	}
+	SetDefault(New(os.Stdout, l))
+	return nil
+}
+
+type contextKey struct{}
+
+// NewContext returns a context carrying l.
+func NewContext(ctx context.Context, l *Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, l)
+}
+
+// FromContext returns the logger in ctx, or the default logger.
+func FromContext(ctx context.Context) *Logger {
+	if l, ok := ctx.Value(contextKey{}).(*Logger); ok {
+		return l
+	}
+	return Default()
+}
+
+// With returns a context whose logger has additional fields.
+func With(ctx context.Context, kv ...interface{}) context.Context {
+	return NewContext(ctx, FromContext(ctx).With(kv...))
+}
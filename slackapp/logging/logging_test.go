@@ -0,0 +1,77 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestLogger(level Level) (*Logger, *bytes.Buffer) {
+	var buf bytes.Buffer
+	l := New(&buf, level)
+	l.now = func() time.Time { return time.Date(2020, 9, 1, 12, 0, 0, 0, time.UTC) }
+	return l, &buf
+}
+
+func TestJSONLines(t *testing.T) {
+	l, buf := newTestLogger(LevelInfo)
+
+	l.With(KeyRequestID, "req-1", KeyUserID, "U0001").Error("failed to open modal", KeyError, errors.New("expired_trigger_id"), KeyChannelID, "")
+
+	want := `{"time":"2020-09-01T12:00:00Z","level":"error","msg":"failed to open modal","request_id":"req-1","user_id":"U0001","error":"expired_trigger_id"}` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+}
+
+func TestLevel(t *testing.T) {
+	l, buf := newTestLogger(LevelWarn)
+
+	l.Debug("debug")
+	l.Info("info")
+	l.Warn("warn")
+	l.Error("error")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[0], `"level":"warn"`) || !strings.Contains(lines[1], `"level":"error"`) {
+		t.Errorf("lines = %q, want warn and error only", lines)
+	}
+}
+
+func TestOverride(t *testing.T) {
+	l, buf := newTestLogger(LevelInfo)
+
+	l.With(KeyUserID, "U0001", KeyTeamID, "T0001").With(KeyUserID, "U0002").Info("hello")
+
+	want := `{"time":"2020-09-01T12:00:00Z","level":"info","msg":"hello","user_id":"U0002","team_id":"T0001"}` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+}
+
+func TestContext(t *testing.T) {
+	l, buf := newTestLogger(LevelInfo)
+
+	ctx := NewContext(context.Background(), l)
+	ctx = With(ctx, KeyTriggerID, "trigger-1")
+	FromContext(ctx).Info("hello")
+
+	if !strings.Contains(buf.String(), `"trigger_id":"trigger-1"`) {
+		t.Errorf("got %s, want trigger_id", buf.String())
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	for s, want := range map[string]Level{"": LevelInfo, "debug": LevelDebug, "WARN": LevelWarn, "error": LevelError} {
+		got, err := ParseLevel(s)
+		if err != nil || got != want {
+			t.Errorf("ParseLevel(%q) = (%v, %v), want %v", s, got, err, want)
+		}
+	}
+	if _, err := ParseLevel("verbose"); err == nil {
+		t.Error("ParseLevel(verbose) succeeded, want error")
+	}
+}
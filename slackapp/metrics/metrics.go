@@ -0,0 +1,128 @@
+// Package metrics records counters like failed Slack API calls.
+// By default they're written to stdout in the CloudWatch embedded metric format, which turns the
+// logs of a Lambda function into metrics without any API calls.
+package metrics
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Namespace is the CloudWatch namespace of the metrics written by the default recorder.
+const Namespace = "SlackModalExamples"
+
+// Recorder records metrics.
+type Recorder interface {
+	// Add adds value to the metric named name with dimensions like {"Method": "chat.postMessage"}.
+	Add(name string, value float64, dimensions map[string]string)
+}
+
+// EMF writes each metric as a JSON line in the CloudWatch embedded metric format.
+// See https://docs.aws.amazon.com/AmazonCloudWatch/latest/monitoring/CloudWatch_Embedded_Metric_Format_Specification.html
+type EMF struct {
+	namespace string
+
+	mu  sync.Mutex
+	w   io.Writer
+	now func() time.Time
+}
+
+// NewEMF returns an EMF writing to w under namespace.
+func NewEMF(w io.Writer, namespace string) *EMF {
+	return &EMF{namespace: namespace, w: w, now: time.Now}
+}
+
+// Add implements Recorder.
+func (e *EMF) Add(name string, value float64, dimensions map[string]string) {
+	keys := make([]string, 0, len(dimensions))
+	for k := range dimensions {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	type metric struct {
+		Name string
+		Unit string
+	}
+	type directive struct {
+		Namespace  string
+		Dimensions [][]string
+		Metrics    []metric
+	}
+	type metadata struct {
+		Timestamp         int64
+		CloudWatchMetrics []directive
+	}
+
+	line := map[string]interface{}{
+		"_aws": metadata{
+			Timestamp: e.now().UnixNano() / int64(time.Millisecond),
+			CloudWatchMetrics: []directive{{
+				Namespace:  e.namespace,
+				Dimensions: [][]string{keys},
+				Metrics:    []metric{{Name: name, Unit: "Count"}},
+			}},
+		},
+		name: value,
+	}
+	for k, v := range dimensions {
+		line[k] = v
+	}
+
+	b, err := json.Marshal(line)
+	if err != nil {
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.w.Write(append(b, '\n'))
+}
+
+// Memory keeps metrics in memory, e.g. to check them in tests.
+type Memory struct {
+	mu     sync.Mutex
+	values map[string]float64
+}
+
+// NewMemory returns an empty Memory.
+func NewMemory() *Memory {
+	return &Memory{values: map[string]float64{}}
+}
+
+// Add implements Recorder.
+func (m *Memory) Add(name string, value float64, dimensions map[string]string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.values[name] += value
+}
+
+// Value returns the sum of the metric named name over all dimensions.
+func (m *Memory) Value(name string) float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.values[name]
+}
+
+var (
+	defaultMu       sync.RWMutex
+	defaultRecorder Recorder = NewEMF(os.Stdout, Namespace)
+)
+
+// Default returns the recorder used when none is given.
+func Default() Recorder {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultRecorder
+}
+
+// SetDefault replaces the recorder used when none is given.
+func SetDefault(r Recorder) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	defaultRecorder = r
+}
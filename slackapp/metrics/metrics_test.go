@@ -0,0 +1,22 @@
+package metrics
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestEMF(t *testing.T) {
+	var buf bytes.Buffer
+	e := NewEMF(&buf, "Test")
+	e.now = func() time.Time { return time.Unix(1600000000, 0) }
+
+	e.Add("SlackAPIFailures", 1, map[string]string{"Reason": "rate_limited", "Method": "chat.postMessage"})
+
+	want := `{"Method":"chat.postMessage","Reason":"rate_limited","SlackAPIFailures":1,` +
+		`"_aws":{"Timestamp":1600000000000,"CloudWatchMetrics":[{"Namespace":"Test","Dimensions":[["Method","Reason"]],` +
+		`"Metrics":[{"Name":"SlackAPIFailures","Unit":"Count"}]}]}}` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+}
@@ -0,0 +1,154 @@
+package slackapp
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambdacontext"
+	"github.com/nicoJN/slack-modal-examples/slackapp/logging"
+)
+
+// AckTimeout is the deadline of a handler. Slack gives up on a request after 3 seconds,
+// so this leaves a margin for API Gateway and the network.
+const AckTimeout = 2500 * time.Millisecond
+
+// Middleware wraps a Handler.
+type Middleware func(next Handler) Handler
+
+// Chain wraps h with middlewares. The first middleware is the outermost one.
+func Chain(h Handler, middlewares ...Middleware) Handler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		h = middlewares[i](h)
+	}
+	return h
+}
+
+// Standard returns the middlewares every handler receiving requests from Slack should use.
+func Standard(signingSecret string) []Middleware {
+	return []Middleware{
+		RequestLogging(),
+		Recover(),
+		Timeout(AckTimeout),
+		VerifySignature(signingSecret),
+	}
+}
+
+// VerifySignature acknowledges requests with an invalid signature without passing them to the handler.
+func VerifySignature(signingSecret string) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+			if verificationSkipped(ctx) {
+				return next(ctx, request)
+			}
+			if err := Verify(request, signingSecret); err != nil {
+				return AckError(ctx, "Failed to verify request", err)
+			}
+			return next(ctx, request)
+		}
+	}
+}
+
+// Recover turns a panic in the handler into a logged error, so that one bad payload doesn't
+// crash the process.
+func Recover() Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, request events.APIGatewayProxyRequest) (res events.APIGatewayProxyResponse, err error) {
+			defer func() {
+				if p := recover(); p != nil {
+					logging.FromContext(ctx).Error("Recovered from panic", "panic", fmt.Sprint(p), "stack", string(debug.Stack()))
+					res, err = OK(), nil
+				}
+			}()
+			return next(ctx, request)
+		}
+	}
+}
+
+// RequestLogging sets a logger tagged with the request ID into the context and logs the result
+// of every request. Handlers add more fields, e.g. the user ID, with logging.With.
+func RequestLogging() Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+			id := requestID(ctx, request)
+			logger := logging.FromContext(ctx).With(logging.KeyRequestID, id)
+			ctx = logging.NewContext(WithRequestID(ctx, id), logger)
+
+			start := time.Now()
+			res, err := next(ctx, request)
+
+			kv := []interface{}{
+				"method", request.HTTPMethod,
+				"path", request.Path,
+				"status", res.StatusCode,
+				"duration_ms", time.Since(start).Milliseconds(),
+			}
+			if err != nil {
+				logger.Error("Request failed", append(kv, logging.KeyError, err)...)
+			} else {
+				logger.Info("Request handled", kv...)
+			}
+			return res, err
+		}
+	}
+}
+
+// requestID returns the ID of the Lambda invocation, or a random ID outside Lambda.
+func requestID(ctx context.Context, request events.APIGatewayProxyRequest) string {
+	if lc, ok := lambdacontext.FromContext(ctx); ok && lc.AwsRequestID != "" {
+		return lc.AwsRequestID
+	}
+	if request.RequestContext.RequestID != "" {
+		return request.RequestContext.RequestID
+	}
+
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(b)
+}
+
+// Timeout cancels the context of the handler after d, or earlier when the context already has a
+// closer deadline. When the handler doesn't return in time, the request is acknowledged anyway.
+func Timeout(d time.Duration) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+			ctx, cancel := context.WithTimeout(ctx, d)
+			defer cancel()
+
+			type result struct {
+				res   events.APIGatewayProxyResponse
+				err   error
+				panic interface{}
+			}
+			ch := make(chan result, 1)
+
+			go func() {
+				// Pass a panic to the caller's goroutine, where Recover can catch it.
+				defer func() {
+					if p := recover(); p != nil {
+						ch <- result{panic: fmt.Sprintf("%v\n%s", p, debug.Stack())}
+					}
+				}()
+				res, err := next(ctx, request)
+				ch <- result{res: res, err: err}
+			}()
+
+			select {
+			case r := <-ch:
+				if r.panic != nil {
+					panic(r.panic)
+				}
+				return r.res, r.err
+			case <-ctx.Done():
+				logging.FromContext(ctx).Error("Handler didn't return in time", logging.KeyError, ctx.Err())
+				return OK(), nil
+			}
+		}
+	}
+}
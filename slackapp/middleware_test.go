@@ -0,0 +1,99 @@
+package slackapp_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/nicoJN/slack-modal-examples/slackapp"
+	"github.com/nicoJN/slack-modal-examples/slackapp/slacktest"
+)
+
+func TestChainOrder(t *testing.T) {
+	var calls []string
+	mw := func(name string) slackapp.Middleware {
+		return func(next slackapp.Handler) slackapp.Handler {
+			return func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+				calls = append(calls, name)
+				return next(ctx, request)
+			}
+		}
+	}
+	h := func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		calls = append(calls, "handler")
+		return slackapp.OK(), nil
+	}
+
+	slackapp.Chain(h, mw("first"), mw("second"))(context.Background(), events.APIGatewayProxyRequest{})
+
+	if got := len(calls); got != 3 || calls[0] != "first" || calls[1] != "second" || calls[2] != "handler" {
+		t.Errorf("calls = %v, want [first second handler]", calls)
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	called := false
+	h := slackapp.Chain(func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		called = true
+		return slackapp.Text("ok"), nil
+	}, slackapp.VerifySignature(slacktest.SigningSecret))
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		request events.APIGatewayProxyRequest
+		want    bool
+	}{
+		{"valid", context.Background(), slacktest.NewRequest(slacktest.SigningSecret, "{}"), true},
+		{"invalid", context.Background(), slacktest.NewRequest("wrong-secret", "{}"), false},
+		{"unsigned", context.Background(), events.APIGatewayProxyRequest{Body: "{}"}, false},
+		{"skipped", slackapp.SkipVerification(context.Background()), events.APIGatewayProxyRequest{Body: "{}"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called = false
+			res, err := h(tt.ctx, tt.request)
+			if err != nil || res.StatusCode != 200 {
+				t.Fatalf("got (%d, %v), want 200 without error", res.StatusCode, err)
+			}
+			if called != tt.want {
+				t.Errorf("handler called = %v, want %v", called, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecover(t *testing.T) {
+	h := slackapp.Chain(func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		var s []string
+		_ = s[0]
+		return slackapp.OK(), nil
+	}, slackapp.Recover(), slackapp.Timeout(time.Second))
+
+	res, err := h(context.Background(), events.APIGatewayProxyRequest{})
+	if err != nil || res.StatusCode != 200 {
+		t.Errorf("got (%d, %v), want 200 without error", res.StatusCode, err)
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	canceled := make(chan struct{})
+	h := slackapp.Chain(func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		<-ctx.Done()
+		close(canceled)
+		time.Sleep(50 * time.Millisecond)
+		return slackapp.Text("too late"), nil
+	}, slackapp.Timeout(10*time.Millisecond))
+
+	res, err := h(context.Background(), events.APIGatewayProxyRequest{})
+	if err != nil || res.Body != "" {
+		t.Errorf("got (%q, %v), want an empty ack", res.Body, err)
+	}
+
+	select {
+	case <-canceled:
+	case <-time.After(time.Second):
+		t.Error("handler context wasn't canceled")
+	}
+}
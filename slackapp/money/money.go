@@ -0,0 +1,174 @@
+// Package money represents amounts of money exactly, as integers of the minor unit of a currency
+// (e.g. cents), instead of floats which can't represent 0.10 exactly.
+//
+// Rounding rules:
+//   - Parse never rounds. An amount with more decimal places than the currency has is an error.
+//   - Arithmetic is done on integers, so it never rounds either.
+//   - Percent is the exception. It rounds half away from zero to the minor unit,
+//     e.g. 15% of $ 0.10 is $ 0.02.
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// currency describes how amounts of a currency are written.
+type currency struct {
+	symbol string
+	digits int // decimal places of the minor unit
+}
+
+var currencies = map[string]currency{
+	"USD": {symbol: "$", digits: 2},
+	"EUR": {symbol: "€", digits: 2},
+	"JPY": {symbol: "¥", digits: 0},
+}
+
+// Known reports whether code is a supported ISO 4217 currency code.
+func Known(code string) bool {
+	_, ok := currencies[code]
+	return ok
+}
+
+// Digits returns the decimal places of the minor unit of a currency, e.g. 2 for USD and 0 for JPY.
+func Digits(code string) int {
+	return currencies[code].digits
+}
+
+// Money is an amount of money.
+type Money struct {
+	Amount   int64  `json:"amount"`   // in the minor unit, e.g. cents for USD
+	Currency string `json:"currency"` // ISO 4217 code, e.g. USD
+}
+
+// New returns an amount in the minor unit of currency, e.g. New(750, "USD") is $ 7.50.
+func New(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+// Errors returned by Parse.
+var (
+	ErrSyntax   = errors.New("not a decimal number")
+	ErrDecimals = errors.New("too many decimal places")
+	ErrRange    = errors.New("out of range")
+)
+
+// Parse parses a decimal number like "7.50" or "-3" in currency.
+// Only digits, an optional leading minus sign and one decimal point are accepted, so inputs like
+// "1e9", "NaN" or "Inf" are errors.
+func Parse(s, code string) (Money, error) {
+	c, ok := currencies[code]
+	if !ok {
+		return Money{}, fmt.Errorf("unknown currency: %q", code)
+	}
+
+	negative := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+
+	whole, frac := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		whole, frac = s[:i], s[i+1:]
+	}
+	if whole == "" && frac == "" || !digits(whole) || !digits(frac) {
+		return Money{}, ErrSyntax
+	}
+	if len(frac) > c.digits {
+		return Money{}, ErrDecimals
+	}
+
+	// Pad the decimals to the minor unit, e.g. "7.5" -> "750".
+	minor := strings.TrimLeft(whole+frac+strings.Repeat("0", c.digits-len(frac)), "0")
+	if minor == "" {
+		minor = "0"
+	}
+	amount, err := strconv.ParseInt(minor, 10, 64)
+	if err != nil {
+		return Money{}, ErrRange
+	}
+
+	if negative {
+		amount = -amount
+	}
+	return New(amount, code), nil
+}
+
+func digits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Add returns m + o. It panics if the currencies differ, which is a programming error.
+func (m Money) Add(o Money) Money {
+	if m.Currency != o.Currency {
+		panic(fmt.Sprintf("money: adding %s to %s", o.Currency, m.Currency))
+	}
+	if (o.Amount > 0 && m.Amount > math.MaxInt64-o.Amount) || (o.Amount < 0 && m.Amount < math.MinInt64-o.Amount) {
+		panic("money: overflow")
+	}
+	return New(m.Amount+o.Amount, m.Currency)
+}
+
+// Mul returns m multiplied by n, e.g. for a quantity.
+func (m Money) Mul(n int64) Money {
+	if n != 0 && (m.Amount*n)/n != m.Amount {
+		panic("money: overflow")
+	}
+	return New(m.Amount*n, m.Currency)
+}
+
+// Percent returns p percent of m, e.g. for a tip, rounded half away from zero to the minor unit.
+func (m Money) Percent(p int64) Money {
+	x := m.Mul(p).Amount
+	if x < 0 {
+		return New(-((-x + 50) / 100), m.Currency)
+	}
+	return New((x+50)/100, m.Currency)
+}
+
+// IsZero reports whether m is zero.
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+// IsNegative reports whether m is less than zero.
+func (m Money) IsNegative() bool {
+	return m.Amount < 0
+}
+
+// Decimal formats m as a decimal number without the currency, e.g. "7.50".
+func (m Money) Decimal() string {
+	d := currencies[m.Currency].digits
+
+	amount := m.Amount
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	s := strconv.FormatInt(amount, 10)
+	if d == 0 {
+		return sign + s
+	}
+	if len(s) <= d {
+		s = strings.Repeat("0", d-len(s)+1) + s
+	}
+	return sign + s[:len(s)-d] + "." + s[len(s)-d:]
+}
+
+// String formats m with the currency symbol, e.g. "$ 7.50".
+func (m Money) String() string {
+	c, ok := currencies[m.Currency]
+	if !ok {
+		return m.Currency + " " + m.Decimal()
+	}
+	return c.symbol + " " + m.Decimal()
+}
@@ -0,0 +1,100 @@
+package money_test
+
+import (
+	"testing"
+
+	"github.com/nicoJN/slack-modal-examples/slackapp/money"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in       string
+		currency string
+		want     int64
+		wantErr  error
+	}{
+		{"7.50", "USD", 750, nil},
+		{"7.5", "USD", 750, nil},
+		{"7", "USD", 700, nil},
+		{".5", "USD", 50, nil},
+		{"0.10", "USD", 10, nil},
+		{"-3", "USD", -300, nil},
+		{"007.00", "USD", 700, nil},
+		{"1200", "JPY", 1200, nil},
+		{"7.505", "USD", 0, money.ErrDecimals},
+		{"1.5", "JPY", 0, money.ErrDecimals},
+		{"1e9", "USD", 0, money.ErrSyntax},
+		{"NaN", "USD", 0, money.ErrSyntax},
+		{"Inf", "USD", 0, money.ErrSyntax},
+		{"+5", "USD", 0, money.ErrSyntax},
+		{"1,000", "USD", 0, money.ErrSyntax},
+		{"", "USD", 0, money.ErrSyntax},
+		{".", "USD", 0, money.ErrSyntax},
+		{"99999999999999999999", "USD", 0, money.ErrRange},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := money.Parse(tt.in, tt.currency)
+			if err != tt.wantErr {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && got != money.New(tt.want, tt.currency) {
+				t.Errorf("got %+v, want %d %s", got, tt.want, tt.currency)
+			}
+		})
+	}
+
+	if _, err := money.Parse("1", "XXX"); err == nil {
+		t.Error("expected an error for an unknown currency")
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		m    money.Money
+		want string
+	}{
+		{money.New(750, "USD"), "$ 7.50"},
+		{money.New(5, "USD"), "$ 0.05"},
+		{money.New(0, "USD"), "$ 0.00"},
+		{money.New(-1999, "USD"), "$ -19.99"},
+		{money.New(1200, "JPY"), "¥ 1200"},
+	}
+	for _, tt := range tests {
+		if got := tt.m.String(); got != tt.want {
+			t.Errorf("%+v = %q, want %q", tt.m, got, tt.want)
+		}
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	// 0.10 + 0.20 is exactly 0.30, unlike with floats.
+	if got := money.New(10, "USD").Add(money.New(20, "USD")); got != money.New(30, "USD") {
+		t.Errorf("0.10 + 0.20 = %v", got)
+	}
+	if got := money.New(250, "USD").Mul(3); got != money.New(750, "USD") {
+		t.Errorf("2.50 * 3 = %v", got)
+	}
+	for _, tt := range []struct {
+		amount, percent, want int64
+	}{
+		{70000, 15, 10500},
+		{10, 15, 2},   // 0.015 rounds up.
+		{10, 14, 1},   // 0.014 rounds down.
+		{-10, 15, -2}, // Away from zero.
+	} {
+		if got := money.New(tt.amount, "USD").Percent(tt.percent); got != money.New(tt.want, "USD") {
+			t.Errorf("%d%% of %d = %v, want %d", tt.percent, tt.amount, got, tt.want)
+		}
+	}
+	if d := money.Digits("JPY"); d != 0 {
+		t.Errorf("digits of JPY = %d, want 0", d)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic when adding different currencies")
+		}
+	}()
+	money.New(1, "USD").Add(money.New(1, "JPY"))
+}
@@ -0,0 +1,96 @@
+package slackapp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/nicoJN/slack-modal-examples/slackapp/logging"
+	"github.com/nicoJN/slack-modal-examples/slackapp/slackapi"
+	"github.com/slack-go/slack"
+)
+
+// ErrorReporter tells users that their request failed, instead of letting nothing happen.
+// The message contains the request ID, so that users can refer to the logs.
+type ErrorReporter struct {
+	api slackapi.Client
+}
+
+// NewErrorReporter returns an ErrorReporter which sends messages through api.
+func NewErrorReporter(api slackapi.Client) *ErrorReporter {
+	return &ErrorReporter{api: api}
+}
+
+// errorText returns a friendly message with the request ID.
+func errorText(ctx context.Context) string {
+	text := ":warning: Sorry, something went wrong. Please try again later."
+	if id := RequestID(ctx); id != "" {
+		text += fmt.Sprintf("\nReference: `%s`", id)
+	}
+	return text
+}
+
+// ErrorView returns a modal which shows a friendly message with the request ID.
+func ErrorView(ctx context.Context) *slack.ModalViewRequest {
+	text := slack.NewTextBlockObject("mrkdwn", errorText(ctx), false, false)
+
+	return &slack.ModalViewRequest{
+		Type:   slack.ViewType("modal"),
+		Title:  slack.NewTextBlockObject("plain_text", "Something went wrong", false, false),
+		Close:  slack.NewTextBlockObject("plain_text", "Close", false, false),
+		Blocks: slack.Blocks{BlockSet: []slack.Block{slack.NewSectionBlock(text, nil, nil)}},
+	}
+}
+
+// ReportInteraction logs err and tells the user who made the interaction.
+//   - view_submission: the modal is updated to an error view through the response.
+//   - block_actions in a modal: the modal is updated to an error view through views.update.
+//   - others: an ephemeral message is posted to the channel.
+//
+// The returned response acknowledges the interaction.
+func (r *ErrorReporter) ReportInteraction(ctx context.Context, message slack.InteractionCallback, err error) (events.APIGatewayProxyResponse, error) {
+	logging.FromContext(ctx).Error("Failed to handle "+string(message.Type), logging.KeyError, err)
+
+	if message.Type == slack.InteractionTypeViewSubmission {
+		return JSON(slack.NewUpdateViewSubmissionResponse(ErrorView(ctx)))
+	}
+
+	if message.View.ID != "" {
+		if _, err := r.api.UpdateViewContext(ctx, *ErrorView(ctx), "", message.View.Hash, message.View.ID); err != nil {
+			logging.FromContext(ctx).Error("Failed to report an error", logging.KeyError, err)
+		}
+		return OK(), nil
+	}
+
+	channelID := message.Channel.ID
+	if channelID == "" {
+		channelID = message.Container.ChannelID
+	}
+	r.postEphemeral(ctx, channelID, message.User.ID)
+
+	return OK(), nil
+}
+
+// ReportEvent logs err and posts an ephemeral message to the user who triggered the event.
+func (r *ErrorReporter) ReportEvent(ctx context.Context, channelID, userID string, err error) (events.APIGatewayProxyResponse, error) {
+	logging.FromContext(ctx).Error("Failed to handle event", logging.KeyError, err)
+	r.postEphemeral(ctx, channelID, userID)
+	return OK(), nil
+}
+
+// Report logs err and posts an ephemeral message to the user.
+// It's for work which runs after the request has been acknowledged, e.g. background jobs.
+func (r *ErrorReporter) Report(ctx context.Context, channelID, userID string, err error) {
+	logging.FromContext(ctx).Error("Failed to run a job", logging.KeyError, err)
+	r.postEphemeral(ctx, channelID, userID)
+}
+
+func (r *ErrorReporter) postEphemeral(ctx context.Context, channelID, userID string) {
+	if channelID == "" || userID == "" {
+		return
+	}
+
+	if _, err := r.api.PostEphemeralContext(ctx, channelID, userID, slack.MsgOptionText(errorText(ctx), false)); err != nil {
+		logging.FromContext(ctx).Error("Failed to report an error", logging.KeyError, err)
+	}
+}
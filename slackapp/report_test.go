@@ -0,0 +1,42 @@
+package slackapp_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/nicoJN/slack-modal-examples/slackapp"
+	"github.com/nicoJN/slack-modal-examples/slackapp/blockkit"
+	"github.com/nicoJN/slack-modal-examples/slackapp/slackapi/slackapitest"
+	"github.com/slack-go/slack"
+)
+
+func TestReportInteractionInModal(t *testing.T) {
+	fake := slackapitest.NewFake()
+	r := slackapp.NewErrorReporter(fake)
+
+	var m slack.InteractionCallback
+	m.Type = slack.InteractionTypeBlockActions
+	m.User.ID = "U0001"
+	m.View.ID = "V0001"
+	m.View.Hash = "hash"
+
+	res, err := r.ReportInteraction(context.Background(), m, errors.New("boom"))
+	if err != nil || res.StatusCode != 200 || res.Body != "" {
+		t.Fatalf("got (%+v, %v), want an empty ack", res, err)
+	}
+
+	views := fake.UpdatedViews()
+	if len(views) != 1 {
+		t.Fatalf("updated %d views, want 1", len(views))
+	}
+	if v := views[0]; v.ViewID != "V0001" || v.Hash != "hash" || v.View.Title.Text != "Something went wrong" {
+		t.Errorf("updated view = %+v, want the error view on V0001", v)
+	}
+	if err := blockkit.ValidateView(views[0].View); err != nil {
+		t.Errorf("error view: %v", err)
+	}
+	if n := len(fake.Ephemerals()); n != 0 {
+		t.Errorf("posted %d ephemeral messages, want 0", n)
+	}
+}
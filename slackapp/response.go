@@ -0,0 +1,50 @@
+package slackapp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/nicoJN/slack-modal-examples/slackapp/logging"
+)
+
+// OK returns an empty response which acknowledges the request.
+func OK() events.APIGatewayProxyResponse {
+	return events.APIGatewayProxyResponse{StatusCode: 200}
+}
+
+// Text returns a response with a plain text body.
+func Text(body string) events.APIGatewayProxyResponse {
+	return events.APIGatewayProxyResponse{
+		StatusCode: 200,
+		Headers: map[string]string{
+			"Content-Type": "text/plain",
+		},
+		Body: body,
+	}
+}
+
+// JSON returns a response with v marshaled as a JSON body.
+func JSON(v interface{}) (events.APIGatewayProxyResponse, error) {
+	bytes, err := json.Marshal(v)
+	if err != nil {
+		return OK(), fmt.Errorf("failed to marshal json: %w", err)
+	}
+
+	return events.APIGatewayProxyResponse{
+		StatusCode:      200,
+		IsBase64Encoded: false,
+		Headers: map[string]string{
+			"Content-Type": "application/json",
+		},
+		Body: string(bytes),
+	}, nil
+}
+
+// AckError logs err and acknowledges the request anyway.
+// Slack retries requests which aren't acknowledged with 200, and a retry doesn't fix these errors.
+func AckError(ctx context.Context, msg string, err error) (events.APIGatewayProxyResponse, error) {
+	logging.FromContext(ctx).Error(msg, logging.KeyError, err)
+	return OK(), nil
+}
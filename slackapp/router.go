@@ -0,0 +1,126 @@
+package slackapp
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/nicoJN/slack-modal-examples/slackapp/logging"
+	"github.com/slack-go/slack"
+)
+
+// InteractionHandler handles a payload from Slack interactive components.
+type InteractionHandler func(ctx context.Context, message slack.InteractionCallback) (events.APIGatewayProxyResponse, error)
+
+// ID matches a callback ID, an action ID or a block ID.
+// The zero value matches any ID.
+type ID struct {
+	value  string
+	prefix bool
+}
+
+// Exact returns an ID which matches v exactly.
+func Exact(v string) ID {
+	return ID{value: v}
+}
+
+// Prefix returns an ID which matches IDs starting with v.
+func Prefix(v string) ID {
+	return ID{value: v, prefix: true}
+}
+
+func (id ID) any() bool {
+	return id.value == "" && !id.prefix
+}
+
+func (id ID) match(s string) bool {
+	if id.any() {
+		return true
+	}
+	if id.prefix {
+		return strings.HasPrefix(s, id.value)
+	}
+	return s == id.value
+}
+
+// Route selects the interactions a handler is registered for.
+// Empty fields match anything.
+//
+// CallbackID is matched against the callback ID of the view (view_submission, view_closed and
+// block_actions in a modal) or of the shortcut. ActionID and BlockID are matched against the
+// actions of a block_actions payload, and a route matches when any of the actions match.
+type Route struct {
+	Type       slack.InteractionType
+	CallbackID ID
+	ActionID   ID
+	BlockID    ID
+}
+
+func (r Route) match(message slack.InteractionCallback) bool {
+	if r.Type != "" && r.Type != message.Type {
+		return false
+	}
+
+	callbackID := message.View.CallbackID
+	if callbackID == "" {
+		callbackID = message.CallbackID
+	}
+	if !r.CallbackID.match(callbackID) {
+		return false
+	}
+
+	if r.ActionID.any() && r.BlockID.any() {
+		return true
+	}
+	for _, action := range message.ActionCallback.BlockActions {
+		if r.ActionID.match(action.ActionID) && r.BlockID.match(action.BlockID) {
+			return true
+		}
+	}
+	return false
+}
+
+type routeEntry struct {
+	route   Route
+	handler InteractionHandler
+}
+
+// Router dispatches interactions to the handler of the first matching route.
+// Interactions which match no route are passed to the fallback handler.
+type Router struct {
+	routes   []routeEntry
+	fallback InteractionHandler
+}
+
+// NewRouter returns a Router whose fallback handler logs and acknowledges the interaction.
+func NewRouter() *Router {
+	return &Router{fallback: unknownInteraction}
+}
+
+// Handle registers a handler for a route. Routes are tried in the order they were registered.
+func (r *Router) Handle(route Route, h InteractionHandler) {
+	r.routes = append(r.routes, routeEntry{route: route, handler: h})
+}
+
+// Fallback replaces the handler for interactions which match no route.
+func (r *Router) Fallback(h InteractionHandler) {
+	r.fallback = h
+}
+
+// Dispatch passes an interaction to the appropriate handler.
+// The logger in the context of the handler is tagged with the IDs of the interaction.
+func (r *Router) Dispatch(ctx context.Context, message slack.InteractionCallback) (events.APIGatewayProxyResponse, error) {
+	ctx = TagInteraction(ctx, message)
+
+	for _, e := range r.routes {
+		if e.route.match(message) {
+			return e.handler(ctx, message)
+		}
+	}
+	return r.fallback(ctx, message)
+}
+
+func unknownInteraction(ctx context.Context, message slack.InteractionCallback) (events.APIGatewayProxyResponse, error) {
+	logging.FromContext(ctx).Error("Unknown request type", "type", message.Type)
+	return OK(), nil
+}
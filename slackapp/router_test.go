@@ -0,0 +1,93 @@
+package slackapp_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/nicoJN/slack-modal-examples/slackapp"
+	"github.com/nicoJN/slack-modal-examples/slackapp/logging"
+	"github.com/slack-go/slack"
+)
+
+// named returns a handler which answers with its name.
+func named(name string) slackapp.InteractionHandler {
+	return func(ctx context.Context, message slack.InteractionCallback) (events.APIGatewayProxyResponse, error) {
+		return slackapp.Text(name), nil
+	}
+}
+
+func TestRouterDispatch(t *testing.T) {
+	r := slackapp.NewRouter()
+	r.Handle(slackapp.Route{Type: slack.InteractionTypeViewSubmission, CallbackID: slackapp.Exact("order")}, named("order"))
+	r.Handle(slackapp.Route{Type: slack.InteractionTypeViewSubmission, CallbackID: slackapp.Prefix("order_")}, named("order_prefix"))
+	r.Handle(slackapp.Route{Type: slack.InteractionTypeBlockActions, ActionID: slackapp.Exact("button"), BlockID: slackapp.Prefix("shop_")}, named("button"))
+	r.Fallback(named("fallback"))
+
+	submission := func(callbackID string) slack.InteractionCallback {
+		var m slack.InteractionCallback
+		m.Type = slack.InteractionTypeViewSubmission
+		m.View.CallbackID = callbackID
+		return m
+	}
+	action := func(actionID, blockID string) slack.InteractionCallback {
+		var m slack.InteractionCallback
+		m.Type = slack.InteractionTypeBlockActions
+		m.ActionCallback.BlockActions = []*slack.BlockAction{{ActionID: "other"}, {ActionID: actionID, BlockID: blockID}}
+		return m
+	}
+
+	closed := submission("order")
+	closed.Type = slack.InteractionTypeViewClosed
+
+	tests := []struct {
+		name    string
+		message slack.InteractionCallback
+		want    string
+	}{
+		{"exact", submission("order"), "order"},
+		{"prefix", submission("order_sushi"), "order_prefix"},
+		{"no match", submission("confirmation"), "fallback"},
+		{"any action", action("button", "shop_ramen"), "button"},
+		{"block mismatch", action("button", "menu"), "fallback"},
+		{"type mismatch", closed, "fallback"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := r.Dispatch(context.Background(), tt.message)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res.Body != tt.want {
+				t.Errorf("dispatched to %q, want %q", res.Body, tt.want)
+			}
+		})
+	}
+}
+
+func TestRouterTagsLogger(t *testing.T) {
+	var buf bytes.Buffer
+	ctx := logging.NewContext(context.Background(), logging.New(&buf, logging.LevelInfo))
+
+	r := slackapp.NewRouter()
+	r.Fallback(func(ctx context.Context, message slack.InteractionCallback) (events.APIGatewayProxyResponse, error) {
+		logging.FromContext(ctx).Info("handled")
+		return slackapp.OK(), nil
+	})
+
+	var m slack.InteractionCallback
+	m.Type = slack.InteractionTypeViewSubmission
+	m.Team.ID = "T0001"
+	m.User.ID = "U0001"
+	m.TriggerID = "trigger-1"
+	m.View.CallbackID = "order"
+	r.Dispatch(ctx, m)
+
+	for _, want := range []string{`"team_id":"T0001"`, `"user_id":"U0001"`, `"trigger_id":"trigger-1"`, `"callback_id":"order"`} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("log %s doesn't contain %s", buf.String(), want)
+		}
+	}
+}
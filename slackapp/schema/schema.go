@@ -0,0 +1,292 @@
+// Package schema defines the inputs of a modal with a Go struct, so that the blocks, the validation rules
+// and the decoding of a submission come from one place instead of block IDs repeated as string literals.
+//
+//	type orderInputs struct {
+//		Size  string `input:"size,static_select" label:"Size"`
+//		Count int    `input:"count" label:"How many?"`
+//		Note  string `input:"note" label:"Note" optional:"true" multiline:"true" max_length:"500"`
+//	}
+//
+// The input tag gives the name of the input and optionally its element. The block ID and the action ID
+// are derived from the name, e.g. block_id_size and action_id_size. The element defaults to
+// plain_text_input for string and int fields, and to checkboxes for []string fields.
+// The other tags are label, hint, placeholder, optional, multiline and max_length.
+package schema
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/nicoJN/slack-modal-examples/slackapp/validate"
+	"github.com/slack-go/slack"
+)
+
+// Elements of inputs.
+const (
+	PlainTextInput    = "plain_text_input"
+	StaticSelect      = "static_select"
+	RadioButtons      = "radio_buttons"
+	Checkboxes        = "checkboxes"
+	MultiStaticSelect = "multi_static_select"
+)
+
+// BlockID returns the block ID of the input named name.
+func BlockID(name string) string {
+	return "block_id_" + name
+}
+
+// ActionID returns the action ID of the input named name.
+func ActionID(name string) string {
+	return "action_id_" + name
+}
+
+// elements are the elements each kind of field can be shown with. The first one is the default.
+var elements = map[reflect.Kind][]string{
+	reflect.String: {PlainTextInput, StaticSelect, RadioButtons},
+	reflect.Int:    {PlainTextInput, StaticSelect, RadioButtons},
+	reflect.Int64:  {PlainTextInput, StaticSelect, RadioButtons},
+	reflect.Slice:  {Checkboxes, MultiStaticSelect},
+}
+
+type field struct {
+	index       int
+	kind        reflect.Kind
+	name        string
+	element     string
+	label       string
+	hint        string
+	placeholder string
+	optional    bool
+	multiline   bool
+	maxLength   int
+}
+
+// Schema is the inputs of a modal defined by a struct.
+type Schema struct {
+	typ    reflect.Type
+	fields []field
+}
+
+// New returns the schema of the struct v, or of the struct v points to.
+// Fields without an input tag are ignored.
+func New(v interface{}) (*Schema, error) {
+	typ := reflect.TypeOf(v)
+	if typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ == nil || typ.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("schema: %T is not a struct", v)
+	}
+
+	s := &Schema{typ: typ}
+	names := map[string]bool{}
+	for i := 0; i < typ.NumField(); i++ {
+		sf := typ.Field(i)
+		tag, ok := sf.Tag.Lookup("input")
+		if !ok {
+			continue
+		}
+
+		f := field{
+			index:       i,
+			kind:        sf.Type.Kind(),
+			label:       sf.Tag.Get("label"),
+			hint:        sf.Tag.Get("hint"),
+			placeholder: sf.Tag.Get("placeholder"),
+			optional:    sf.Tag.Get("optional") == "true",
+			multiline:   sf.Tag.Get("multiline") == "true",
+		}
+		f.name, f.element = tag, ""
+		if i := strings.IndexByte(tag, ','); i >= 0 {
+			f.name, f.element = tag[:i], tag[i+1:]
+		}
+
+		allowed, ok := elements[f.kind]
+		if !ok || (f.kind == reflect.Slice && sf.Type.Elem().Kind() != reflect.String) {
+			return nil, fmt.Errorf("schema: field %s: unsupported type %s", sf.Name, sf.Type)
+		}
+		if f.element == "" {
+			f.element = allowed[0]
+		}
+		if !contains(allowed, f.element) {
+			return nil, fmt.Errorf("schema: field %s: %s can't be shown with %s", sf.Name, sf.Type, f.element)
+		}
+
+		if f.name == "" || names[f.name] {
+			return nil, fmt.Errorf("schema: field %s: empty or duplicate input name %q", sf.Name, f.name)
+		}
+		names[f.name] = true
+		if f.label == "" {
+			return nil, fmt.Errorf("schema: field %s: label is empty", sf.Name)
+		}
+
+		if ml := sf.Tag.Get("max_length"); ml != "" {
+			n, err := strconv.Atoi(ml)
+			if err != nil || n <= 0 {
+				return nil, fmt.Errorf("schema: field %s: invalid max_length %q", sf.Name, ml)
+			}
+			f.maxLength = n
+		}
+
+		s.fields = append(s.fields, f)
+	}
+	return s, nil
+}
+
+// Must is like New but panics on an error. It's for schemas of package variables.
+func Must(v interface{}) *Schema {
+	s, err := New(v)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+func contains(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
+
+// Options are the options of selects, radio buttons and checkboxes, keyed by input name.
+type Options map[string][]*slack.OptionBlockObject
+
+// Blocks returns an input block for each field, in the order of the fields.
+// options must have the options of every input which isn't a plain_text_input.
+func (s *Schema) Blocks(options Options) ([]slack.Block, error) {
+	var blocks []slack.Block
+	for _, f := range s.fields {
+		var placeholder *slack.TextBlockObject
+		if f.placeholder != "" {
+			placeholder = slack.NewTextBlockObject("plain_text", f.placeholder, false, false)
+		}
+
+		opts := options[f.name]
+		if f.element != PlainTextInput && len(opts) == 0 {
+			return nil, fmt.Errorf("schema: no options for %s", f.name)
+		}
+
+		var element slack.BlockElement
+		switch f.element {
+		case PlainTextInput:
+			e := slack.NewPlainTextInputBlockElement(placeholder, ActionID(f.name))
+			e.Multiline = f.multiline
+			e.MaxLength = f.maxLength
+			element = e
+		case StaticSelect:
+			element = slack.NewOptionsSelectBlockElement(slack.OptTypeStatic, placeholder, ActionID(f.name), opts...)
+		case RadioButtons:
+			element = slack.NewRadioButtonsBlockElement(ActionID(f.name), opts...)
+		case Checkboxes:
+			element = slack.NewCheckboxGroupsBlockElement(ActionID(f.name), opts...)
+		case MultiStaticSelect:
+			element = slack.NewOptionsMultiSelectBlockElement(slack.MultiOptTypeStatic, placeholder, ActionID(f.name), opts...)
+		}
+
+		label := slack.NewTextBlockObject("plain_text", f.label, false, false)
+		input := slack.NewInputBlock(BlockID(f.name), label, element)
+		if f.hint != "" {
+			input.Hint = slack.NewTextBlockObject("plain_text", f.hint, false, false)
+		}
+		input.Optional = f.optional
+		blocks = append(blocks, input)
+	}
+	return blocks, nil
+}
+
+// Form returns the validation rules which follow from the tags and the types of the fields:
+// required unless optional, max_length, and whole numbers for int fields.
+// Add rules which depend on the data, e.g. the choices of a select, to the returned form.
+func (s *Schema) Form() *validate.Form {
+	form := validate.NewForm()
+	for _, f := range s.fields {
+		var rules []validate.Rule
+		if !f.optional {
+			rules = append(rules, validate.Required())
+		}
+		if f.kind == reflect.Int || f.kind == reflect.Int64 {
+			rules = append(rules, validate.Numeric().WithMessage(wholeNumber), validate.Decimals(0).WithMessage(wholeNumber))
+		}
+		if f.maxLength > 0 {
+			rules = append(rules, validate.MaxLength(f.maxLength))
+		}
+		form.Field(BlockID(f.name), ActionID(f.name), rules...)
+	}
+	return form
+}
+
+const wholeNumber = "Enter a whole number."
+
+// Errors are the messages of the inputs which couldn't be decoded, keyed by block ID like the errors of a
+// view_submission response.
+type Errors map[string]string
+
+func (e Errors) Error() string {
+	var keys []string
+	for k := range e {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var msgs []string
+	for _, k := range keys {
+		msgs = append(msgs, k+": "+e[k])
+	}
+	return "schema: invalid inputs: " + strings.Join(msgs, "; ")
+}
+
+// Decode sets the input values in state to the fields of dst, which points to a struct of the schema.
+// Values are trimmed, and empty inputs leave the zero value. When some values don't fit the types of
+// the fields, e.g. "a lot" for an int, it returns Errors after setting the other fields.
+func (s *Schema) Decode(state *slack.ViewState, dst interface{}) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Type() != s.typ {
+		return fmt.Errorf("schema: can't decode into %T, want *%s", dst, s.typ)
+	}
+	v = v.Elem()
+
+	values := validate.Values{}
+	if state != nil {
+		values = state.Values
+	}
+
+	errors := Errors{}
+	for _, f := range s.fields {
+		fv := v.Field(f.index)
+		switch f.kind {
+		case reflect.String:
+			fv.SetString(values.Get(BlockID(f.name), ActionID(f.name)))
+
+		case reflect.Int, reflect.Int64:
+			raw := values.Get(BlockID(f.name), ActionID(f.name))
+			if raw == "" {
+				fv.SetInt(0)
+				continue
+			}
+			n, err := strconv.ParseInt(raw, 10, fv.Type().Bits())
+			if err != nil {
+				errors[BlockID(f.name)] = wholeNumber
+				continue
+			}
+			fv.SetInt(n)
+
+		case reflect.Slice:
+			var selected []string
+			for _, o := range values[BlockID(f.name)][ActionID(f.name)].SelectedOptions {
+				selected = append(selected, o.Value)
+			}
+			fv.Set(reflect.ValueOf(selected).Convert(fv.Type()))
+		}
+	}
+
+	if len(errors) > 0 {
+		return errors
+	}
+	return nil
+}
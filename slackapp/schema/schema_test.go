@@ -0,0 +1,141 @@
+package schema_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/nicoJN/slack-modal-examples/slackapp/schema"
+	"github.com/nicoJN/slack-modal-examples/slackapp/slacktest"
+	"github.com/slack-go/slack"
+)
+
+type inputs struct {
+	Size     string   `input:"size,static_select" label:"Size"`
+	Count    int      `input:"count" label:"How many?" placeholder:"1"`
+	Toppings []string `input:"toppings" label:"Toppings" optional:"true"`
+	Note     string   `input:"note" label:"Note" hint:"Anything else?" optional:"true" multiline:"true" max_length:"5"`
+	Ignored  string
+}
+
+func options(values ...string) []*slack.OptionBlockObject {
+	var opts []*slack.OptionBlockObject
+	for _, v := range values {
+		opts = append(opts, slack.NewOptionBlockObject(v, slack.NewTextBlockObject("plain_text", v, false, false)))
+	}
+	return opts
+}
+
+func TestBlocks(t *testing.T) {
+	s := schema.Must(inputs{})
+
+	blocks, err := s.Blocks(schema.Options{"size": options("s", "l"), "toppings": options("bacon")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(blocks) != 4 {
+		t.Fatalf("got %d blocks, want 4", len(blocks))
+	}
+
+	size := blocks[0].(*slack.InputBlock)
+	if size.BlockID != "block_id_size" || size.Optional {
+		t.Errorf("size = %+v, want a required block_id_size", size)
+	}
+	if e, ok := size.Element.(*slack.SelectBlockElement); !ok || e.ActionID != "action_id_size" || len(e.Options) != 2 {
+		t.Errorf("size element = %+v, want a static_select with 2 options", size.Element)
+	}
+	if _, ok := blocks[2].(*slack.InputBlock).Element.(*slack.CheckboxGroupsBlockElement); !ok {
+		t.Errorf("toppings element = %+v, want checkboxes", blocks[2].(*slack.InputBlock).Element)
+	}
+	note := blocks[3].(*slack.InputBlock)
+	if e := note.Element.(*slack.PlainTextInputBlockElement); !e.Multiline || e.MaxLength != 5 || !note.Optional || note.Hint == nil {
+		t.Errorf("note = %+v, want an optional multiline input with a hint and max length 5", note)
+	}
+
+	if _, err := s.Blocks(nil); err == nil {
+		t.Error("expected an error for a select without options")
+	}
+}
+
+func TestDecode(t *testing.T) {
+	s := schema.Must(&inputs{})
+
+	values := slacktest.Values(
+		slacktest.Selected("block_id_size", "action_id_size", "l"),
+		slacktest.Text("block_id_count", "action_id_count", " 3 "),
+		slacktest.Checked("block_id_toppings", "action_id_toppings", "bacon", "egg"),
+		slacktest.Text("block_id_note", "action_id_note", "hi"),
+	)
+	var got inputs
+	if err := s.Decode(&slack.ViewState{Values: values}, &got); err != nil {
+		t.Fatal(err)
+	}
+	want := inputs{Size: "l", Count: 3, Toppings: []string{"bacon", "egg"}, Note: "hi"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("decoded %+v, want %+v", got, want)
+	}
+
+	// Type errors are keyed by block ID, and the other fields are still set.
+	got = inputs{}
+	values = slacktest.Values(
+		slacktest.Selected("block_id_size", "action_id_size", "s"),
+		slacktest.Text("block_id_count", "action_id_count", "a lot"),
+	)
+	err := s.Decode(&slack.ViewState{Values: values}, &got)
+	errors, ok := err.(schema.Errors)
+	if !ok || errors["block_id_count"] == "" || got.Size != "s" {
+		t.Errorf("err = %v, decoded %+v, want an error for block_id_count", err, got)
+	}
+
+	if err := s.Decode(nil, &struct{}{}); err == nil {
+		t.Error("expected an error for a struct of another schema")
+	}
+}
+
+func TestForm(t *testing.T) {
+	s := schema.Must(inputs{})
+
+	values := slacktest.Values(
+		slacktest.Text("block_id_count", "action_id_count", "1.5"),
+		slacktest.Text("block_id_note", "action_id_note", "too long"),
+	)
+	errors := s.Form().Validate(&slack.ViewState{Values: values})
+	for _, blockID := range []string{"block_id_size", "block_id_count", "block_id_note"} {
+		if errors[blockID] == "" {
+			t.Errorf("errors = %v, want an error for %s", errors, blockID)
+		}
+	}
+	if _, ok := errors["block_id_toppings"]; ok {
+		t.Errorf("errors = %v, want none for the optional toppings", errors)
+	}
+}
+
+func TestInvalidSchemas(t *testing.T) {
+	tests := []struct {
+		name string
+		v    interface{}
+		want string
+	}{
+		{"not a struct", "inputs", "not a struct"},
+		{"unsupported type", struct {
+			F float64 `input:"f" label:"F"`
+		}{}, "unsupported type"},
+		{"wrong element", struct {
+			F []string `input:"f,plain_text_input" label:"F"`
+		}{}, "can't be shown"},
+		{"duplicate name", struct {
+			A string `input:"a" label:"A"`
+			B string `input:"a" label:"B"`
+		}{}, "duplicate input name"},
+		{"no label", struct {
+			A string `input:"a"`
+		}{}, "label is empty"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := schema.New(tt.v); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want an error about %q", err, tt.want)
+			}
+		})
+	}
+}
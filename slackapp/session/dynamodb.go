@@ -0,0 +1,90 @@
+package session
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awssession "github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+)
+
+// DynamoDBStore keeps sessions in a DynamoDB table, which is shared by every Lambda container.
+//
+// The table has a string partition key named key. Each item also has the value as a binary attribute
+// and the expiry in Unix seconds as expires_at, which can be the TTL attribute of the table. TTL
+// deletes expired items only eventually, so Load checks the expiry itself.
+type DynamoDBStore struct {
+	client dynamodbiface.DynamoDBAPI
+	table  string
+}
+
+// NewDynamoDBStore returns a DynamoDBStore on table through client.
+func NewDynamoDBStore(client dynamodbiface.DynamoDBAPI, table string) *DynamoDBStore {
+	return &DynamoDBStore{client: client, table: table}
+}
+
+// NewDynamoDBStoreFromEnv returns a DynamoDBStore with the region and the credentials which the Lambda
+// runtime sets to the environment.
+func NewDynamoDBStoreFromEnv(table string) (*DynamoDBStore, error) {
+	sess, err := awssession.NewSession()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create an AWS session: %w", err)
+	}
+	return NewDynamoDBStore(dynamodb.New(sess), table), nil
+}
+
+// Save implements Store.
+func (s *DynamoDBStore) Save(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	_, err := s.client.PutItemWithContext(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(s.table),
+		Item: map[string]*dynamodb.AttributeValue{
+			"key":        {S: aws.String(key)},
+			"value":      {B: value},
+			"expires_at": {N: aws.String(strconv.FormatInt(time.Now().Add(ttl).Unix(), 10))},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save session: %w", err)
+	}
+	return nil
+}
+
+// Load implements Store.
+func (s *DynamoDBStore) Load(ctx context.Context, key string) ([]byte, error) {
+	out, err := s.client.GetItemWithContext(ctx, &dynamodb.GetItemInput{
+		TableName:      aws.String(s.table),
+		Key:            map[string]*dynamodb.AttributeValue{"key": {S: aws.String(key)}},
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load session: %w", err)
+	}
+	value, expiresAt := out.Item["value"], out.Item["expires_at"]
+	if value == nil || expiresAt == nil {
+		return nil, ErrNotFound
+	}
+	expires, err := strconv.ParseInt(aws.StringValue(expiresAt.N), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the expiry of session %s: %w", key, err)
+	}
+	if expires <= time.Now().Unix() {
+		return nil, ErrNotFound
+	}
+	return value.B, nil
+}
+
+// Delete implements Store.
+func (s *DynamoDBStore) Delete(ctx context.Context, key string) error {
+	_, err := s.client.DeleteItemWithContext(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(s.table),
+		Key:       map[string]*dynamodb.AttributeValue{"key": {S: aws.String(key)}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+// Package session keeps the state of a conversation with a user across views, e.g. an order in progress,
+// on the server. Only the key of a session is kept in the private_metadata of a view, which is capped at
+// 3000 characters and would overflow with a long note or a large cart.
+package session
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/nicoJN/slack-modal-examples/slackapp/internal/jsonfile"
+)
+
+// ErrNotFound is returned by Load when a session doesn't exist or has expired.
+var ErrNotFound = errors.New("session: not found")
+
+// Store keeps sessions for a while.
+type Store interface {
+	// Save keeps value as the session key until ttl passes, replacing the session if it exists.
+	Save(ctx context.Context, key string, value []byte, ttl time.Duration) error
+
+	// Load returns the value of the session key, or ErrNotFound.
+	Load(ctx context.Context, key string) ([]byte, error)
+
+	// Delete forgets the session key, e.g. once the order is complete. A missing session isn't an error.
+	Delete(ctx context.Context, key string) error
+}
+
+type entry struct {
+	Value   []byte    `json:"value"`
+	Expires time.Time `json:"expires"`
+}
+
+// entries maps keys to sessions.
+type entries map[string]entry
+
+// expire drops the expired sessions.
+func (e entries) expire(now time.Time) {
+	for k, v := range e {
+		if !now.Before(v.Expires) {
+			delete(e, k)
+		}
+	}
+}
+
+// MemoryStore keeps sessions in memory. It's shared only by the requests to one process, e.g. one warm
+// Lambda container.
+type MemoryStore struct {
+	mu       sync.Mutex
+	sessions entries
+}
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{sessions: entries{}}
+}
+
+// Save implements Store.
+func (s *MemoryStore) Save(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.sessions.expire(now)
+	s.sessions[key] = entry{Value: append([]byte(nil), value...), Expires: now.Add(ttl)}
+	return nil
+}
+
+// Load implements Store.
+func (s *MemoryStore) Load(ctx context.Context, key string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.sessions[key]
+	if !ok || !time.Now().Before(e.Expires) {
+		return nil, ErrNotFound
+	}
+	return append([]byte(nil), e.Value...), nil
+}
+
+// Delete implements Store.
+func (s *MemoryStore) Delete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.sessions, key)
+	return nil
+}
+
+// FileStore keeps sessions in a JSON file, so that they survive restarts of the process.
+// The file must not be shared by multiple processes.
+type FileStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileStore returns a FileStore backed by path. The file is created on the first save.
+func NewFileStore(path string) *FileStore {
+	return &FileStore{path: path}
+}
+
+// Save implements Store.
+func (s *FileStore) Save(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sessions, err := s.load()
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	sessions.expire(now)
+	sessions[key] = entry{Value: value, Expires: now.Add(ttl)}
+	return s.save(sessions)
+}
+
+// Load implements Store.
+func (s *FileStore) Load(ctx context.Context, key string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sessions, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	e, ok := sessions[key]
+	if !ok || !time.Now().Before(e.Expires) {
+		return nil, ErrNotFound
+	}
+	return e.Value, nil
+}
+
+// Delete implements Store.
+func (s *FileStore) Delete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sessions, err := s.load()
+	if err != nil {
+		return err
+	}
+	if _, ok := sessions[key]; !ok {
+		return nil
+	}
+	delete(sessions, key)
+	return s.save(sessions)
+}
+
+func (s *FileStore) load() (entries, error) {
+	sessions := entries{}
+	if err := jsonfile.Read(s.path, &sessions); err != nil {
+		return nil, fmt.Errorf("failed to read session file: %w", err)
+	}
+	return sessions, nil
+}
+
+func (s *FileStore) save(sessions entries) error {
+	if err := jsonfile.Write(s.path, sessions); err != nil {
+		return fmt.Errorf("failed to write session file: %w", err)
+	}
+	return nil
+}
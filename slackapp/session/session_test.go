@@ -0,0 +1,167 @@
+package session_test
+
+import (
+	"context"
+	"database/sql"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/nicoJN/slack-modal-examples/slackapp/session"
+	_ "modernc.org/sqlite"
+)
+
+func TestStores(t *testing.T) {
+	dir, err := ioutil.TempDir("", "session")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	stores := map[string]func() session.Store{
+		"memory": func() session.Store { return session.NewMemoryStore() },
+		"file":   func() session.Store { return session.NewFileStore(filepath.Join(dir, "sessions.json")) },
+		"sqlite": func() session.Store { return newSQLiteStore(t, filepath.Join(dir, "sessions.db")) },
+		"dynamodb": func() session.Store {
+			return session.NewDynamoDBStore(&fakeDynamoDB{t: t, items: map[string]map[string]*dynamodb.AttributeValue{}}, "sessions")
+		},
+	}
+	for name, newStore := range stores {
+		t.Run(name, func(t *testing.T) {
+			s := newStore()
+			ctx := context.Background()
+
+			load := func(key, want string) {
+				t.Helper()
+				got, err := s.Load(ctx, key)
+				if want == "" {
+					if err != session.ErrNotFound {
+						t.Errorf("Load(%s) = (%s, %v), want ErrNotFound", key, got, err)
+					}
+					return
+				}
+				if err != nil || string(got) != want {
+					t.Errorf("Load(%s) = (%s, %v), want %s", key, got, err, want)
+				}
+			}
+
+			load("V0001", "")
+			if err := s.Save(ctx, "V0001", []byte(`{"cart":1}`), time.Hour); err != nil {
+				t.Fatal(err)
+			}
+			load("V0001", `{"cart":1}`)
+
+			// Saving again replaces the session.
+			if err := s.Save(ctx, "V0001", []byte(`{"cart":2}`), time.Hour); err != nil {
+				t.Fatal(err)
+			}
+			load("V0001", `{"cart":2}`)
+
+			if err := s.Delete(ctx, "V0001"); err != nil {
+				t.Fatal(err)
+			}
+			load("V0001", "")
+			if err := s.Delete(ctx, "V0001"); err != nil {
+				t.Errorf("Delete of a missing session = %v, want nil", err)
+			}
+
+			// An expired session is gone.
+			if err := s.Save(ctx, "V0002", []byte("{}"), 10*time.Millisecond); err != nil {
+				t.Fatal(err)
+			}
+			time.Sleep(20 * time.Millisecond)
+			load("V0002", "")
+		})
+	}
+}
+
+func TestFileStorePersists(t *testing.T) {
+	dir, err := ioutil.TempDir("", "session")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "sessions.json")
+	ctx := context.Background()
+	if err := session.NewFileStore(path).Save(ctx, "V0001", []byte("{}"), time.Hour); err != nil {
+		t.Fatal(err)
+	}
+
+	// Another store on the same file, e.g. after a restart, sees the session.
+	if got, err := session.NewFileStore(path).Load(ctx, "V0001"); err != nil || string(got) != "{}" {
+		t.Errorf("Load = (%s, %v), want {}", got, err)
+	}
+}
+
+func TestSQLiteStoreShared(t *testing.T) {
+	dir, err := ioutil.TempDir("", "session")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "sessions.db")
+	ctx := context.Background()
+	if err := newSQLiteStore(t, path).Save(ctx, "V0001", []byte("{}"), time.Hour); err != nil {
+		t.Fatal(err)
+	}
+
+	// Another process on the same database sees the session.
+	if got, err := newSQLiteStore(t, path).Load(ctx, "V0001"); err != nil || string(got) != "{}" {
+		t.Errorf("Load = (%s, %v), want {}", got, err)
+	}
+}
+
+// newSQLiteStore opens the SQLite database at path with the pure Go driver of modernc.org/sqlite.
+func newSQLiteStore(t *testing.T, path string) *session.SQLiteStore {
+	t.Helper()
+
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	s := session.NewSQLiteStore(db)
+	if err := s.CreateTable(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// fakeDynamoDB keeps the items of the sessions table of the DynamoDB client in a map.
+type fakeDynamoDB struct {
+	dynamodbiface.DynamoDBAPI
+	t     *testing.T
+	items map[string]map[string]*dynamodb.AttributeValue
+}
+
+func (f *fakeDynamoDB) key(table *string, key map[string]*dynamodb.AttributeValue) string {
+	if aws.StringValue(table) != "sessions" {
+		f.t.Errorf("table = %s, want sessions", aws.StringValue(table))
+	}
+	return aws.StringValue(key["key"].S)
+}
+
+func (f *fakeDynamoDB) PutItemWithContext(ctx aws.Context, input *dynamodb.PutItemInput, opts ...request.Option) (*dynamodb.PutItemOutput, error) {
+	f.items[f.key(input.TableName, input.Item)] = input.Item
+	return &dynamodb.PutItemOutput{}, nil
+}
+
+func (f *fakeDynamoDB) GetItemWithContext(ctx aws.Context, input *dynamodb.GetItemInput, opts ...request.Option) (*dynamodb.GetItemOutput, error) {
+	if !aws.BoolValue(input.ConsistentRead) {
+		f.t.Error("GetItem isn't a consistent read")
+	}
+	return &dynamodb.GetItemOutput{Item: f.items[f.key(input.TableName, input.Key)]}, nil
+}
+
+func (f *fakeDynamoDB) DeleteItemWithContext(ctx aws.Context, input *dynamodb.DeleteItemInput, opts ...request.Option) (*dynamodb.DeleteItemOutput, error) {
+	delete(f.items, f.key(input.TableName, input.Key))
+	return &dynamodb.DeleteItemOutput{}, nil
+}
@@ -0,0 +1,68 @@
+package session
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// SQLiteStore keeps sessions in a table of a SQLite database, which can be shared by processes on one
+// host or on a shared file system.
+//
+// This package doesn't bundle a driver. Register one in main (e.g. with a blank import of
+// modernc.org/sqlite, which the tests run on) and open the database with sql.Open.
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteStore returns a SQLiteStore on db. Call CreateTable once before using it.
+func NewSQLiteStore(db *sql.DB) *SQLiteStore {
+	return &SQLiteStore{db: db}
+}
+
+// CreateTable creates the sessions table unless it exists.
+func (s *SQLiteStore) CreateTable(ctx context.Context) error {
+	_, err := s.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS sessions (
+		key        TEXT PRIMARY KEY,
+		value      BLOB NOT NULL,
+		expires_at INTEGER NOT NULL
+	)`)
+	if err != nil {
+		return fmt.Errorf("failed to create the sessions table: %w", err)
+	}
+	return nil
+}
+
+// Save implements Store. It also drops the expired sessions.
+func (s *SQLiteStore) Save(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	now := time.Now()
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM sessions WHERE expires_at <= ?`, now.UnixNano()); err != nil {
+		return fmt.Errorf("failed to delete expired sessions: %w", err)
+	}
+	if _, err := s.db.ExecContext(ctx, `INSERT OR REPLACE INTO sessions (key, value, expires_at) VALUES (?, ?, ?)`, key, value, now.Add(ttl).UnixNano()); err != nil {
+		return fmt.Errorf("failed to save session: %w", err)
+	}
+	return nil
+}
+
+// Load implements Store.
+func (s *SQLiteStore) Load(ctx context.Context, key string) ([]byte, error) {
+	var value []byte
+	err := s.db.QueryRowContext(ctx, `SELECT value FROM sessions WHERE key = ? AND expires_at > ?`, key, time.Now().UnixNano()).Scan(&value)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load session: %w", err)
+	}
+	return value, nil
+}
+
+// Delete implements Store.
+func (s *SQLiteStore) Delete(ctx context.Context, key string) error {
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM sessions WHERE key = ?`, key); err != nil {
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
+	return nil
+}
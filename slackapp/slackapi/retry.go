@@ -0,0 +1,200 @@
+package slackapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"time"
+
+	"github.com/nicoJN/slack-modal-examples/slackapp/logging"
+	"github.com/nicoJN/slack-modal-examples/slackapp/metrics"
+	"github.com/slack-go/slack"
+)
+
+// Names of the metrics recorded by Retrying.
+const (
+	MetricRetries  = "SlackAPIRetries"
+	MetricFailures = "SlackAPIFailures"
+)
+
+// Retrying is a Client which retries calls failed by rate limits and transient errors.
+//   - Rate limited: it waits as long as Slack tells with Retry-After.
+//   - 5xx responses and network errors: it waits with exponential backoff and jitter.
+//
+// It gives up when the wait would exceed the deadline of the context, e.g. the ack deadline of a
+// request. Final failures are counted in the MetricFailures metric.
+//
+// NOTE: A retried chat.postMessage may post twice if the first call reached Slack before it failed.
+type Retrying struct {
+	api     Client
+	metrics metrics.Recorder
+
+	maxAttempts int
+	minDelay    time.Duration
+	maxDelay    time.Duration
+
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// RetryOption configures a Retrying.
+type RetryOption func(*Retrying)
+
+// WithMaxAttempts sets how many times a call is made at most. The default is 3.
+func WithMaxAttempts(n int) RetryOption {
+	return func(r *Retrying) {
+		r.maxAttempts = n
+	}
+}
+
+// WithBackoff sets the bounds of the wait between attempts after transient errors.
+// The default is from 200ms to 2s.
+func WithBackoff(min, max time.Duration) RetryOption {
+	return func(r *Retrying) {
+		r.minDelay = min
+		r.maxDelay = max
+	}
+}
+
+// WithMetrics replaces the recorder of failures, which is metrics.Default() by default.
+func WithMetrics(m metrics.Recorder) RetryOption {
+	return func(r *Retrying) {
+		r.metrics = m
+	}
+}
+
+// NewRetrying wraps api with retries.
+func NewRetrying(api Client, opts ...RetryOption) *Retrying {
+	r := &Retrying{
+		api:         api,
+		metrics:     metrics.Default(),
+		maxAttempts: 3,
+		minDelay:    200 * time.Millisecond,
+		maxDelay:    2 * time.Second,
+		sleep:       sleep,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// PostMessageContext implements Client.
+func (r *Retrying) PostMessageContext(ctx context.Context, channelID string, options ...slack.MsgOption) (channel, timestamp string, err error) {
+	err = r.do(ctx, "chat.postMessage", func() error {
+		channel, timestamp, err = r.api.PostMessageContext(ctx, channelID, options...)
+		return err
+	})
+	return channel, timestamp, err
+}
+
+// PostEphemeralContext implements Client.
+func (r *Retrying) PostEphemeralContext(ctx context.Context, channelID, userID string, options ...slack.MsgOption) (timestamp string, err error) {
+	err = r.do(ctx, "chat.postEphemeral", func() error {
+		timestamp, err = r.api.PostEphemeralContext(ctx, channelID, userID, options...)
+		return err
+	})
+	return timestamp, err
+}
+
+// OpenViewContext implements Client.
+func (r *Retrying) OpenViewContext(ctx context.Context, triggerID string, view slack.ModalViewRequest) (res *slack.ViewResponse, err error) {
+	err = r.do(ctx, "views.open", func() error {
+		res, err = r.api.OpenViewContext(ctx, triggerID, view)
+		return err
+	})
+	return res, err
+}
+
+// UpdateViewContext implements Client.
+func (r *Retrying) UpdateViewContext(ctx context.Context, view slack.ModalViewRequest, externalID, hash, viewID string) (res *slack.ViewResponse, err error) {
+	err = r.do(ctx, "views.update", func() error {
+		res, err = r.api.UpdateViewContext(ctx, view, externalID, hash, viewID)
+		return err
+	})
+	return res, err
+}
+
+// do calls f until it succeeds, it fails permanently or the attempts run out.
+func (r *Retrying) do(ctx context.Context, method string, f func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := f()
+		if err == nil {
+			return nil
+		}
+
+		reason, wait, retryable := r.classify(err, attempt)
+		if !retryable || attempt >= r.maxAttempts || !r.fits(ctx, wait) {
+			r.metrics.Add(MetricFailures, 1, map[string]string{"Method": method, "Reason": reason})
+			return err
+		}
+
+		logging.FromContext(ctx).Warn("Retrying Slack API call", "method", method, "reason", reason, "attempt", attempt, "wait_ms", wait.Milliseconds(), logging.KeyError, err)
+		r.metrics.Add(MetricRetries, 1, map[string]string{"Method": method, "Reason": reason})
+
+		if err := r.sleep(ctx, wait); err != nil {
+			r.metrics.Add(MetricFailures, 1, map[string]string{"Method": method, "Reason": reason})
+			return fmt.Errorf("%s: gave up retrying: %w", method, err)
+		}
+	}
+}
+
+// classify tells whether err is worth retrying, and how long to wait before the next attempt.
+func (r *Retrying) classify(err error, attempt int) (reason string, wait time.Duration, retryable bool) {
+	var rateLimited *slack.RateLimitedError
+	if errors.As(err, &rateLimited) {
+		return "rate_limited", rateLimited.RetryAfter, true
+	}
+
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return "canceled", 0, false
+	}
+
+	// 5xx responses from slack-go/slack.
+	var status interface {
+		HTTPStatusCode() int
+		Retryable() bool
+	}
+	if errors.As(err, &status) && status.Retryable() {
+		return "server_error", r.backoff(attempt), true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return "network", r.backoff(attempt), true
+	}
+
+	// e.g. invalid_auth or expired_trigger_id. Retrying doesn't help.
+	return "error", 0, false
+}
+
+// backoff returns an exponential delay with full jitter over its upper half.
+func (r *Retrying) backoff(attempt int) time.Duration {
+	d := r.minDelay << uint(attempt-1)
+	if d > r.maxDelay || d <= 0 {
+		d = r.maxDelay
+	}
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// fits reports whether an attempt after wait can still finish before the deadline of ctx.
+func (r *Retrying) fits(ctx context.Context, wait time.Duration) bool {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return true
+	}
+	return time.Now().Add(wait).Before(deadline)
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
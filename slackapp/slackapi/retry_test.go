@@ -0,0 +1,128 @@
+package slackapi
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/nicoJN/slack-modal-examples/slackapp/metrics"
+	"github.com/slack-go/slack"
+)
+
+// flaky fails with errs in order, then succeeds.
+type flaky struct {
+	Client
+	errs  []error
+	calls int
+}
+
+func (f *flaky) PostMessageContext(ctx context.Context, channelID string, options ...slack.MsgOption) (string, string, error) {
+	f.calls++
+	if len(f.errs) > 0 {
+		err := f.errs[0]
+		f.errs = f.errs[1:]
+		return "", "", err
+	}
+	return channelID, "1600000000.000100", nil
+}
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+var _ net.Error = timeoutError{}
+
+func TestRetrying(t *testing.T) {
+	tests := []struct {
+		name      string
+		errs      []error
+		wantCalls int
+		wantErr   bool
+		wantWaits []time.Duration
+	}{
+		{"success", nil, 1, false, nil},
+		{"rate limited", []error{&slack.RateLimitedError{RetryAfter: time.Second}}, 2, false, []time.Duration{time.Second}},
+		{"network", []error{&net.OpError{Op: "dial", Err: timeoutError{}}}, 2, false, nil},
+		{"permanent", []error{errors.New("channel_not_found")}, 1, true, nil},
+		{"attempts run out", []error{
+			&slack.RateLimitedError{RetryAfter: time.Second},
+			&slack.RateLimitedError{RetryAfter: time.Second},
+			&slack.RateLimitedError{RetryAfter: time.Second},
+		}, 3, true, []time.Duration{time.Second, time.Second}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := &flaky{errs: tt.errs}
+			m := metrics.NewMemory()
+			r := NewRetrying(api, WithMetrics(m))
+
+			var waits []time.Duration
+			r.sleep = func(ctx context.Context, d time.Duration) error {
+				waits = append(waits, d)
+				return nil
+			}
+
+			_, _, err := r.PostMessageContext(context.Background(), "C0001")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, want error %v", err, tt.wantErr)
+			}
+			if api.calls != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", api.calls, tt.wantCalls)
+			}
+			if tt.wantWaits != nil && len(waits) != len(tt.wantWaits) {
+				t.Errorf("waits = %v, want %v", waits, tt.wantWaits)
+			}
+			for i := range tt.wantWaits {
+				if i < len(waits) && waits[i] != tt.wantWaits[i] {
+					t.Errorf("waits = %v, want %v", waits, tt.wantWaits)
+				}
+			}
+
+			failures := 0.0
+			if tt.wantErr {
+				failures = 1
+			}
+			if got := m.Value(MetricFailures); got != failures {
+				t.Errorf("failures = %v, want %v", got, failures)
+			}
+			if got := m.Value(MetricRetries); got != float64(tt.wantCalls-1) {
+				t.Errorf("retries = %v, want %d", got, tt.wantCalls-1)
+			}
+		})
+	}
+}
+
+func TestRetryingWithinDeadline(t *testing.T) {
+	api := &flaky{errs: []error{&slack.RateLimitedError{RetryAfter: 30 * time.Second}}}
+	m := metrics.NewMemory()
+	r := NewRetrying(api, WithMetrics(m))
+
+	// Waiting 30 seconds would miss the ack deadline, so give up at once.
+	ctx, cancel := context.WithTimeout(context.Background(), 2500*time.Millisecond)
+	defer cancel()
+
+	if _, _, err := r.PostMessageContext(ctx, "C0001"); err == nil {
+		t.Fatal("expected an error")
+	}
+	if api.calls != 1 {
+		t.Errorf("calls = %d, want 1", api.calls)
+	}
+	if got := m.Value(MetricFailures); got != 1 {
+		t.Errorf("failures = %v, want 1", got)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	r := NewRetrying(nil, WithBackoff(100*time.Millisecond, 300*time.Millisecond))
+
+	for attempt, max := range []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 300 * time.Millisecond, 300 * time.Millisecond} {
+		d := r.backoff(attempt + 1)
+		if d < max/2 || d > max {
+			t.Errorf("backoff(%d) = %v, want between %v and %v", attempt+1, d, max/2, max)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+// Package slackapi defines the Slack Web API calls used by the handlers.
+package slackapi
+
+import (
+	"context"
+
+	"github.com/slack-go/slack"
+)
+
+// Client is the subset of the Slack Web API used by the handlers.
+// *slack.Client satisfies this interface.
+type Client interface {
+	PostMessageContext(ctx context.Context, channelID string, options ...slack.MsgOption) (string, string, error)
+	PostEphemeralContext(ctx context.Context, channelID, userID string, options ...slack.MsgOption) (string, error)
+	OpenViewContext(ctx context.Context, triggerID string, view slack.ModalViewRequest) (*slack.ViewResponse, error)
+	UpdateViewContext(ctx context.Context, view slack.ModalViewRequest, externalID, hash, viewID string) (*slack.ViewResponse, error)
+}
+
+var _ Client = (*slack.Client)(nil)
+
+// New returns a Client which calls the real Slack Web API with a bot token.
+// Views and messages are checked against the limits of Block Kit before they're sent,
+// and calls failed by rate limits and transient errors are retried.
+func New(token string) Client {
+	return NewValidating(NewRetrying(slack.New(token)))
+}
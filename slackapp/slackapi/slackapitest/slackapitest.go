@@ -0,0 +1,200 @@
+// Package slackapitest provides an in-memory slackapi.Client for tests.
+package slackapitest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"sync"
+
+	"github.com/nicoJN/slack-modal-examples/slackapp/slackapi"
+	"github.com/slack-go/slack"
+)
+
+var _ slackapi.Client = (*Fake)(nil)
+
+// Message is a message posted through the fake.
+type Message struct {
+	ChannelID string
+	Text      string
+	Blocks    slack.Blocks
+
+	// Values is the raw form sent to chat.postMessage.
+	Values url.Values
+}
+
+// Ephemeral is an ephemeral message posted through the fake.
+type Ephemeral struct {
+	Message
+	UserID string
+}
+
+// OpenedView is a view opened through the fake.
+type OpenedView struct {
+	TriggerID string
+	View      slack.ModalViewRequest
+}
+
+// UpdatedView is a view updated through the fake.
+type UpdatedView struct {
+	ViewID     string
+	ExternalID string
+	Hash       string
+	View       slack.ModalViewRequest
+}
+
+// Fake is a slackapi.Client which records calls instead of sending them to Slack.
+// Set the error fields to make the corresponding calls fail.
+type Fake struct {
+	PostMessageError   error
+	PostEphemeralError error
+	OpenViewError      error
+	UpdateViewError    error
+
+	mu           sync.Mutex
+	messages     []Message
+	ephemerals   []Ephemeral
+	views        []OpenedView
+	updatedViews []UpdatedView
+}
+
+// NewFake returns an empty Fake.
+func NewFake() *Fake {
+	return &Fake{}
+}
+
+// PostMessageContext records a message.
+func (f *Fake) PostMessageContext(ctx context.Context, channelID string, options ...slack.MsgOption) (string, string, error) {
+	if f.PostMessageError != nil {
+		return "", "", f.PostMessageError
+	}
+
+	m, err := newMessage(channelID, options)
+	if err != nil {
+		return "", "", err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.messages = append(f.messages, m)
+
+	return channelID, strconv.Itoa(len(f.messages)), nil
+}
+
+// PostEphemeralContext records an ephemeral message.
+func (f *Fake) PostEphemeralContext(ctx context.Context, channelID, userID string, options ...slack.MsgOption) (string, error) {
+	if f.PostEphemeralError != nil {
+		return "", f.PostEphemeralError
+	}
+
+	m, err := newMessage(channelID, options)
+	if err != nil {
+		return "", err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.ephemerals = append(f.ephemerals, Ephemeral{Message: m, UserID: userID})
+
+	return strconv.Itoa(len(f.ephemerals)), nil
+}
+
+func newMessage(channelID string, options []slack.MsgOption) (Message, error) {
+	_, values, err := slack.UnsafeApplyMsgOptions("", channelID, "", options...)
+	if err != nil {
+		return Message{}, fmt.Errorf("failed to apply message options: %w", err)
+	}
+
+	m := Message{
+		ChannelID: channelID,
+		Text:      values.Get("text"),
+		Values:    values,
+	}
+	if b := values.Get("blocks"); b != "" {
+		if err := json.Unmarshal([]byte(b), &m.Blocks); err != nil {
+			return Message{}, fmt.Errorf("failed to unmarshal blocks: %w", err)
+		}
+	}
+	return m, nil
+}
+
+// OpenViewContext records a view.
+func (f *Fake) OpenViewContext(ctx context.Context, triggerID string, view slack.ModalViewRequest) (*slack.ViewResponse, error) {
+	if f.OpenViewError != nil {
+		return nil, f.OpenViewError
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.views = append(f.views, OpenedView{TriggerID: triggerID, View: view})
+
+	return viewResponse(view, "V"+strconv.Itoa(len(f.views))), nil
+}
+
+// UpdateViewContext records an updated view.
+func (f *Fake) UpdateViewContext(ctx context.Context, view slack.ModalViewRequest, externalID, hash, viewID string) (*slack.ViewResponse, error) {
+	if f.UpdateViewError != nil {
+		return nil, f.UpdateViewError
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.updatedViews = append(f.updatedViews, UpdatedView{ViewID: viewID, ExternalID: externalID, Hash: hash, View: view})
+
+	return viewResponse(view, viewID), nil
+}
+
+// Messages returns the messages posted so far.
+func (f *Fake) Messages() []Message {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Message(nil), f.messages...)
+}
+
+// Ephemerals returns the ephemeral messages posted so far.
+func (f *Fake) Ephemerals() []Ephemeral {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Ephemeral(nil), f.ephemerals...)
+}
+
+// OpenedViews returns the views opened so far.
+func (f *Fake) OpenedViews() []OpenedView {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]OpenedView(nil), f.views...)
+}
+
+// UpdatedViews returns the views updated so far.
+func (f *Fake) UpdatedViews() []UpdatedView {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]UpdatedView(nil), f.updatedViews...)
+}
+
+// Reset forgets the recorded calls.
+func (f *Fake) Reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.messages = nil
+	f.ephemerals = nil
+	f.views = nil
+	f.updatedViews = nil
+}
+
+func viewResponse(view slack.ModalViewRequest, id string) *slack.ViewResponse {
+	var res slack.ViewResponse
+	res.Ok = true
+	res.ID = id
+	res.Type = view.Type
+	res.Title = view.Title
+	res.Close = view.Close
+	res.Submit = view.Submit
+	res.Blocks = view.Blocks
+	res.PrivateMetadata = view.PrivateMetadata
+	res.CallbackID = view.CallbackID
+	res.ExternalID = view.ExternalID
+	return &res
+}
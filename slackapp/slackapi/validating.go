@@ -0,0 +1,52 @@
+package slackapi
+
+import (
+	"context"
+
+	"github.com/nicoJN/slack-modal-examples/slackapp/blockkit"
+	"github.com/slack-go/slack"
+)
+
+// Validating is a Client which checks views and messages against the limits of Block Kit before it
+// sends them. A call which would break a limit fails with blockkit.Violations without reaching Slack,
+// so the log tells which block is wrong instead of Slack's invalid_blocks.
+type Validating struct {
+	api Client
+}
+
+// NewValidating wraps api with the checks of the limits of Block Kit.
+func NewValidating(api Client) *Validating {
+	return &Validating{api: api}
+}
+
+// PostMessageContext implements Client.
+func (v *Validating) PostMessageContext(ctx context.Context, channelID string, options ...slack.MsgOption) (string, string, error) {
+	if err := blockkit.ValidateMessage(options...); err != nil {
+		return "", "", err
+	}
+	return v.api.PostMessageContext(ctx, channelID, options...)
+}
+
+// PostEphemeralContext implements Client.
+func (v *Validating) PostEphemeralContext(ctx context.Context, channelID, userID string, options ...slack.MsgOption) (string, error) {
+	if err := blockkit.ValidateMessage(options...); err != nil {
+		return "", err
+	}
+	return v.api.PostEphemeralContext(ctx, channelID, userID, options...)
+}
+
+// OpenViewContext implements Client.
+func (v *Validating) OpenViewContext(ctx context.Context, triggerID string, view slack.ModalViewRequest) (*slack.ViewResponse, error) {
+	if err := blockkit.ValidateView(view); err != nil {
+		return nil, err
+	}
+	return v.api.OpenViewContext(ctx, triggerID, view)
+}
+
+// UpdateViewContext implements Client.
+func (v *Validating) UpdateViewContext(ctx context.Context, view slack.ModalViewRequest, externalID, hash, viewID string) (*slack.ViewResponse, error) {
+	if err := blockkit.ValidateView(view); err != nil {
+		return nil, err
+	}
+	return v.api.UpdateViewContext(ctx, view, externalID, hash, viewID)
+}
@@ -0,0 +1,45 @@
+package slackapi
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/nicoJN/slack-modal-examples/slackapp/blockkit"
+	"github.com/slack-go/slack"
+)
+
+// recorder records the views it's asked to open.
+type recorder struct {
+	Client
+	opened []slack.ModalViewRequest
+}
+
+func (r *recorder) OpenViewContext(ctx context.Context, triggerID string, view slack.ModalViewRequest) (*slack.ViewResponse, error) {
+	r.opened = append(r.opened, view)
+	return &slack.ViewResponse{}, nil
+}
+
+func TestValidating(t *testing.T) {
+	api := &recorder{}
+	v := NewValidating(api)
+
+	view := slack.ModalViewRequest{
+		Type:   slack.ViewType("modal"),
+		Title:  slack.NewTextBlockObject("plain_text", "Hungryman Hamburgers", false, false),
+		Blocks: slack.Blocks{BlockSet: []slack.Block{slack.NewDividerBlock()}},
+	}
+	if _, err := v.OpenViewContext(context.Background(), "trigger", view); err != nil {
+		t.Fatal(err)
+	}
+
+	// A view which breaks a limit isn't sent.
+	view.PrivateMetadata = strings.Repeat("x", blockkit.MaxPrivateMetadata+1)
+	_, err := v.OpenViewContext(context.Background(), "trigger", view)
+	if _, ok := err.(blockkit.Violations); !ok {
+		t.Errorf("err = %v, want violations", err)
+	}
+	if len(api.opened) != 1 {
+		t.Errorf("opened %d views, want 1", len(api.opened))
+	}
+}
@@ -0,0 +1,31 @@
+// Package slackapp provides the plumbing shared by the Slack handlers:
+// signature verification, response helpers and adapters between API Gateway and net/http.
+package slackapp
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/slack-go/slack"
+)
+
+// Handler is the signature of a handler invoked by API Gateway.
+type Handler func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error)
+
+// Verify returns the result of slack signing secret verification.
+func Verify(request events.APIGatewayProxyRequest, signingSecret string) error {
+	body := request.Body
+	header := http.Header{}
+	for k, v := range request.Headers {
+		header.Set(k, v)
+	}
+
+	sv, err := slack.NewSecretsVerifier(header, signingSecret)
+	if err != nil {
+		return err
+	}
+
+	sv.Write([]byte(body))
+	return sv.Ensure()
+}
@@ -0,0 +1,206 @@
+// Package slacktest builds signed requests like the ones Slack sends and decodes the responses,
+// so that tests can drive the handlers without the real Slack.
+package slacktest
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/slack-go/slack"
+)
+
+// SigningSecret is a signing secret for tests.
+const SigningSecret = "test-signing-secret"
+
+// NewRequest returns a request with body signed by secret.
+func NewRequest(secret, body string) events.APIGatewayProxyRequest {
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+
+	return events.APIGatewayProxyRequest{
+		HTTPMethod: "POST",
+		Headers: map[string]string{
+			"X-Slack-Request-Timestamp": ts,
+			"X-Slack-Signature":         Signature(secret, ts, body),
+		},
+		Body: body,
+	}
+}
+
+// Signature returns the X-Slack-Signature header value for body.
+func Signature(secret, timestamp, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("v0:" + timestamp + ":" + body))
+	return "v0=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// NewEventRequest returns a signed Events API request which wraps an inner event.
+func NewEventRequest(secret string, innerEvent interface{}) (events.APIGatewayProxyRequest, error) {
+	inner, err := json.Marshal(innerEvent)
+	if err != nil {
+		return events.APIGatewayProxyRequest{}, fmt.Errorf("failed to marshal inner event: %w", err)
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"token":      "test-token",
+		"team_id":    "T0001",
+		"api_app_id": "A0001",
+		"type":       "event_callback",
+		"event_id":   "Ev0001",
+		"event_time": time.Now().Unix(),
+		"event":      json.RawMessage(inner),
+	})
+	if err != nil {
+		return events.APIGatewayProxyRequest{}, fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	return NewRequest(secret, string(body)), nil
+}
+
+// AppMention returns an app_mention inner event.
+func AppMention(channelID, userID, text string) map[string]interface{} {
+	return map[string]interface{}{
+		"type":    "app_mention",
+		"user":    userID,
+		"text":    text,
+		"ts":      "1600000000.000100",
+		"channel": channelID,
+	}
+}
+
+// NewInteractionRequest returns a signed request from interactive components.
+func NewInteractionRequest(secret string, message slack.InteractionCallback) (events.APIGatewayProxyRequest, error) {
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return events.APIGatewayProxyRequest{}, fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	form := url.Values{}
+	form.Set("payload", string(payload))
+
+	request := NewRequest(secret, form.Encode())
+	request.Headers["Content-Type"] = "application/x-www-form-urlencoded"
+	return request, nil
+}
+
+// ButtonPushed returns a block_actions payload of a button pushed in a message.
+func ButtonPushed(userID, channelID, actionID, value string) slack.InteractionCallback {
+	var message slack.InteractionCallback
+	message.Type = slack.InteractionTypeBlockActions
+	message.TriggerID = "trigger-" + actionID
+	message.User.ID = userID
+	message.Channel.ID = channelID
+	message.Container.Type = "message"
+	message.Container.ChannelID = channelID
+	message.ActionCallback.BlockActions = []*slack.BlockAction{
+		{ActionID: actionID, BlockID: "block-" + actionID, Value: value, Type: "button"},
+	}
+	return message
+}
+
+// ViewButtonPushed returns a block_actions payload of a button pushed in a view.
+func ViewButtonPushed(userID string, view slack.ModalViewRequest, actionID, value string) slack.InteractionCallback {
+	var message slack.InteractionCallback
+	message.Type = slack.InteractionTypeBlockActions
+	message.TriggerID = "trigger-" + actionID
+	message.User.ID = userID
+	message.Container.Type = "view"
+	message.Container.ViewID = "V0001"
+	message.View.ID = "V0001"
+	message.View.Hash = "hash"
+	message.View.Type = view.Type
+	message.View.Title = view.Title
+	message.View.Blocks = view.Blocks
+	message.View.CallbackID = view.CallbackID
+	message.View.ExternalID = view.ExternalID
+	message.View.PrivateMetadata = view.PrivateMetadata
+	message.ActionCallback.BlockActions = []*slack.BlockAction{
+		{ActionID: actionID, BlockID: "block-" + actionID, Value: value, Type: "button"},
+	}
+	return message
+}
+
+// ViewSubmission returns a view_submission payload of a view with the given input values.
+// values is keyed by block ID and action ID like View.State.Values.
+func ViewSubmission(userID string, view slack.ModalViewRequest, values map[string]map[string]slack.BlockAction) slack.InteractionCallback {
+	var message slack.InteractionCallback
+	message.Type = slack.InteractionTypeViewSubmission
+	message.User.ID = userID
+	message.View.ID = "V0001"
+	message.View.Hash = "hash"
+	message.View.Type = view.Type
+	message.View.Title = view.Title
+	message.View.Blocks = view.Blocks
+	message.View.CallbackID = view.CallbackID
+	message.View.ExternalID = view.ExternalID
+	message.View.PrivateMetadata = view.PrivateMetadata
+	message.View.State = &slack.ViewState{Values: values}
+	return message
+}
+
+// Text returns an input value of a plain_text_input.
+func Text(blockID, actionID, value string) map[string]map[string]slack.BlockAction {
+	return map[string]map[string]slack.BlockAction{
+		blockID: {actionID: {Type: "plain_text_input", Value: value}},
+	}
+}
+
+// Selected returns an input value of a radio_buttons or a static_select.
+func Selected(blockID, actionID, value string) map[string]map[string]slack.BlockAction {
+	return map[string]map[string]slack.BlockAction{
+		blockID: {actionID: {Type: "static_select", SelectedOption: slack.OptionBlockObject{Value: value}}},
+	}
+}
+
+// Checked returns an input value of checkboxes or a multi_static_select.
+func Checked(blockID, actionID string, values ...string) map[string]map[string]slack.BlockAction {
+	var options []slack.OptionBlockObject
+	for _, v := range values {
+		options = append(options, slack.OptionBlockObject{Value: v})
+	}
+	return map[string]map[string]slack.BlockAction{
+		blockID: {actionID: {Type: "checkboxes", SelectedOptions: options}},
+	}
+}
+
+// Values merges input values.
+func Values(values ...map[string]map[string]slack.BlockAction) map[string]map[string]slack.BlockAction {
+	merged := map[string]map[string]slack.BlockAction{}
+	for _, v := range values {
+		for blockID, actions := range v {
+			if merged[blockID] == nil {
+				merged[blockID] = map[string]slack.BlockAction{}
+			}
+			for actionID, action := range actions {
+				merged[blockID][actionID] = action
+			}
+		}
+	}
+	return merged
+}
+
+// DecodeViewSubmissionResponse decodes a response to a view_submission.
+func DecodeViewSubmissionResponse(response events.APIGatewayProxyResponse) (*slack.ViewSubmissionResponse, error) {
+	var res slack.ViewSubmissionResponse
+	if err := json.Unmarshal([]byte(response.Body), &res); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal view submission response: %w", err)
+	}
+	return &res, nil
+}
+
+// Texts returns the texts of the section blocks, which tests usually assert on.
+func Texts(blocks slack.Blocks) []string {
+	var texts []string
+	for _, b := range blocks.BlockSet {
+		if s, ok := b.(*slack.SectionBlock); ok && s.Text != nil {
+			texts = append(texts, s.Text.Text)
+		}
+	}
+	return texts
+}
@@ -0,0 +1,280 @@
+// Package socketmode receives events and interactions over a Socket Mode WebSocket
+// instead of HTTP webhooks, so that the app works without a public endpoint.
+//
+// See https://api.slack.com/apis/connections/socket
+package socketmode
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/gorilla/websocket"
+	"github.com/nicoJN/slack-modal-examples/slackapp"
+	"github.com/nicoJN/slack-modal-examples/slackapp/logging"
+	"github.com/slack-go/slack"
+)
+
+// Types of envelopes.
+const (
+	TypeHello         = "hello"
+	TypeDisconnect    = "disconnect"
+	TypeEventsAPI     = "events_api"
+	TypeInteractive   = "interactive"
+	TypeSlashCommands = "slash_commands"
+)
+
+// Envelope is a message sent by Slack over the WebSocket.
+type Envelope struct {
+	EnvelopeID             string          `json:"envelope_id,omitempty"`
+	Type                   string          `json:"type"`
+	Payload                json.RawMessage `json:"payload,omitempty"`
+	AcceptsResponsePayload bool            `json:"accepts_response_payload,omitempty"`
+	RetryAttempt           int             `json:"retry_attempt,omitempty"`
+	RetryReason            string          `json:"retry_reason,omitempty"`
+
+	// Reason is set on disconnect envelopes.
+	Reason string `json:"reason,omitempty"`
+}
+
+// Ack acknowledges an envelope.
+// Payload is the response to an interaction, e.g. response_action of a view_submission.
+type Ack struct {
+	EnvelopeID string          `json:"envelope_id"`
+	Payload    json.RawMessage `json:"payload,omitempty"`
+}
+
+// Client keeps a Socket Mode connection and dispatches envelopes to the handlers.
+// Envelopes are passed to the same handlers as HTTP requests, so the handlers run with their
+// middlewares. Signature verification is skipped because the connection is authenticated by the
+// app-level token.
+type Client struct {
+	appToken      string
+	onEvent       slackapp.Handler
+	onInteraction slackapp.Handler
+
+	apiURL     string
+	httpClient *http.Client
+	dialer     *websocket.Dialer
+	minBackoff time.Duration
+	maxBackoff time.Duration
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithAPIURL replaces the Slack Web API URL, e.g. with socketmodetest.Server.
+func WithAPIURL(apiURL string) Option {
+	return func(c *Client) {
+		c.apiURL = apiURL
+	}
+}
+
+// WithHTTPClient replaces the HTTP client used to open connections.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithBackoff sets the range of the wait before reconnecting after a failure.
+func WithBackoff(min, max time.Duration) Option {
+	return func(c *Client) {
+		c.minBackoff = min
+		c.maxBackoff = max
+	}
+}
+
+// New returns a Client authenticated by an app-level token (xapp-...).
+func New(appToken string, onEvent, onInteraction slackapp.Handler, opts ...Option) *Client {
+	c := &Client{
+		appToken:      appToken,
+		onEvent:       onEvent,
+		onInteraction: onInteraction,
+		apiURL:        slack.APIURL,
+		httpClient:    http.DefaultClient,
+		dialer:        websocket.DefaultDialer,
+		minBackoff:    time.Second,
+		maxBackoff:    time.Minute,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Run connects to Slack and handles envelopes until ctx is canceled.
+// It reconnects when Slack asks to or when the connection fails.
+func (c *Client) Run(ctx context.Context) error {
+	backoff := c.minBackoff
+	for {
+		connected, err := c.serve(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if connected {
+			backoff = c.minBackoff
+		}
+		if err == nil {
+			// Slack asked to reconnect.
+			continue
+		}
+
+		logging.FromContext(ctx).Error("Socket Mode connection failed", "backoff", backoff.String(), logging.KeyError, err)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > c.maxBackoff {
+			backoff = c.maxBackoff
+		}
+	}
+}
+
+// serve opens a connection and handles envelopes until Slack sends a disconnect envelope.
+// connected reports whether Slack said hello on this connection.
+func (c *Client) serve(ctx context.Context) (connected bool, err error) {
+	wsURL, err := c.openConnection(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	conn, _, err := c.dialer.DialContext(ctx, wsURL, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to dial: %w", err)
+	}
+
+	// Close the connection when ctx is canceled, which unblocks ReadJSON.
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-done:
+		}
+		conn.Close()
+	}()
+
+	// Envelopes are handled concurrently, while a connection allows only one writer at a time.
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	defer wg.Wait()
+	ack := func(a Ack) error {
+		mu.Lock()
+		defer mu.Unlock()
+		return conn.WriteJSON(a)
+	}
+
+	for {
+		var env Envelope
+		if err := conn.ReadJSON(&env); err != nil {
+			return connected, fmt.Errorf("failed to read envelope: %w", err)
+		}
+
+		switch env.Type {
+		case TypeHello:
+			connected = true
+			logging.FromContext(ctx).Info("Socket Mode connected")
+		case TypeDisconnect:
+			logging.FromContext(ctx).Info("Socket Mode disconnect requested", "reason", env.Reason)
+			return connected, nil
+		default:
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if err := ack(c.handle(ctx, env)); err != nil {
+					logging.FromContext(ctx).Error("Failed to ack envelope", "envelope_id", env.EnvelopeID, logging.KeyError, err)
+				}
+			}()
+		}
+	}
+}
+
+// handle dispatches an envelope to the handlers and returns its ack.
+func (c *Client) handle(ctx context.Context, env Envelope) Ack {
+	a := Ack{EnvelopeID: env.EnvelopeID}
+	ctx = slackapp.SkipVerification(ctx)
+
+	// Build the request Slack would send over HTTP.
+	request := events.APIGatewayProxyRequest{
+		HTTPMethod: "POST",
+		Path:       env.Type,
+		Headers:    map[string]string{},
+	}
+	if env.RetryAttempt > 0 {
+		request.Headers[slackapp.HeaderRetryNum] = strconv.Itoa(env.RetryAttempt)
+		request.Headers[slackapp.HeaderRetryReason] = env.RetryReason
+	}
+
+	switch env.Type {
+	case TypeEventsAPI:
+		request.Headers["Content-Type"] = "application/json"
+		request.Body = string(env.Payload)
+
+		if _, err := c.onEvent(ctx, request); err != nil {
+			logging.FromContext(ctx).Error("Failed to handle event", logging.KeyError, err)
+		}
+	case TypeInteractive:
+		request.Headers["Content-Type"] = "application/x-www-form-urlencoded"
+		request.Body = url.Values{"payload": {string(env.Payload)}}.Encode()
+
+		res, err := c.onInteraction(ctx, request)
+		if err != nil {
+			logging.FromContext(ctx).Error("Failed to handle interaction", logging.KeyError, err)
+			return a
+		}
+
+		// The response body over HTTP becomes the payload of the ack.
+		if env.AcceptsResponsePayload && res.Body != "" && json.Valid([]byte(res.Body)) {
+			a.Payload = json.RawMessage(res.Body)
+		}
+	default:
+		logging.FromContext(ctx).Error("Unsupported envelope type", "type", env.Type)
+	}
+
+	return a
+}
+
+// openConnection calls apps.connections.open and returns the WebSocket URL.
+func (c *Client) openConnection(ctx context.Context) (string, error) {
+	req, err := http.NewRequest("POST", c.apiURL+"apps.connections.open", strings.NewReader(url.Values{}.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Authorization", "Bearer "+c.appToken)
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to open connection: %w", err)
+	}
+	defer res.Body.Close()
+
+	var body struct {
+		OK    bool   `json:"ok"`
+		URL   string `json:"url"`
+		Error string `json:"error"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("failed to decode apps.connections.open response: %w", err)
+	}
+	if !body.OK {
+		return "", errors.New("apps.connections.open failed: " + body.Error)
+	}
+
+	return body.URL, nil
+}
@@ -0,0 +1,146 @@
+package socketmode_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/nicoJN/slack-modal-examples/slackapp"
+	"github.com/nicoJN/slack-modal-examples/slackapp/socketmode"
+	"github.com/nicoJN/slack-modal-examples/slackapp/socketmode/socketmodetest"
+	"github.com/slack-go/slack"
+)
+
+const timeout = 3 * time.Second
+
+// start runs a client against a stand-in server until the test ends.
+func start(t *testing.T, onEvent, onInteraction slackapp.Handler) *socketmodetest.Server {
+	t.Helper()
+
+	server := socketmodetest.NewServer()
+	client := socketmode.New("xapp-test", onEvent, onInteraction,
+		socketmode.WithAPIURL(server.APIURL()),
+		socketmode.WithBackoff(10*time.Millisecond, 100*time.Millisecond),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		client.Run(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		server.Close()
+		<-done
+	})
+
+	if err := server.WaitConnections(1, timeout); err != nil {
+		t.Fatal(err)
+	}
+	return server
+}
+
+func noop(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	return slackapp.OK(), nil
+}
+
+func TestEventsAPI(t *testing.T) {
+	received := make(chan string, 1)
+	onEvent := func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		var v struct {
+			EventID string `json:"event_id"`
+		}
+		json.Unmarshal([]byte(request.Body), &v)
+		received <- v.EventID
+		return slackapp.OK(), nil
+	}
+	server := start(t, onEvent, noop)
+
+	err := server.Send(socketmode.Envelope{
+		EnvelopeID: "env-1",
+		Type:       socketmode.TypeEventsAPI,
+		Payload:    json.RawMessage(`{"type":"event_callback","event_id":"Ev0001","event":{"type":"app_mention"}}`),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a, err := server.Ack(timeout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.EnvelopeID != "env-1" {
+		t.Errorf("acked %q, want env-1", a.EnvelopeID)
+	}
+	if got := <-received; got != "Ev0001" {
+		t.Errorf("received event %q, want Ev0001", got)
+	}
+}
+
+func TestInteractiveResponsePayload(t *testing.T) {
+	onInteraction := func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		form, err := url.ParseQuery(request.Body)
+		if err != nil {
+			return slackapp.OK(), err
+		}
+		var message slack.InteractionCallback
+		if err := json.Unmarshal([]byte(form.Get("payload")), &message); err != nil {
+			return slackapp.OK(), err
+		}
+		return slackapp.JSON(slack.NewErrorsViewSubmissionResponse(map[string]string{"block_id": message.View.CallbackID}))
+	}
+	server := start(t, noop, onInteraction)
+
+	err := server.Send(socketmode.Envelope{
+		EnvelopeID:             "env-2",
+		Type:                   socketmode.TypeInteractive,
+		Payload:                json.RawMessage(`{"type":"view_submission","view":{"callback_id":"callback"}}`),
+		AcceptsResponsePayload: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a, err := server.Ack(timeout)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var res slack.ViewSubmissionResponse
+	if err := json.Unmarshal(a.Payload, &res); err != nil {
+		t.Fatalf("failed to unmarshal ack payload %s: %v", a.Payload, err)
+	}
+	if res.ResponseAction != slack.RAErrors || res.Errors["block_id"] != "callback" {
+		t.Errorf("ack payload = %s, want errors response", a.Payload)
+	}
+}
+
+func TestReconnect(t *testing.T) {
+	server := start(t, noop, noop)
+
+	// Slack asks to reconnect.
+	if err := server.Disconnect("refresh_requested"); err != nil {
+		t.Fatal(err)
+	}
+	if err := server.WaitConnections(2, timeout); err != nil {
+		t.Fatal(err)
+	}
+
+	// The connection is lost.
+	server.Drop()
+	if err := server.WaitConnections(3, timeout); err != nil {
+		t.Fatal(err)
+	}
+
+	// The new connection still works.
+	if err := server.Send(socketmode.Envelope{EnvelopeID: "env-3", Type: socketmode.TypeEventsAPI, Payload: json.RawMessage(`{}`)}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := server.Ack(timeout); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,154 @@
+// Package socketmodetest provides a local stand-in for the Slack Socket Mode endpoint.
+package socketmodetest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/nicoJN/slack-modal-examples/slackapp/socketmode"
+)
+
+// Server serves apps.connections.open and the WebSocket it points to.
+// It talks to one client connection at a time.
+type Server struct {
+	server   *httptest.Server
+	upgrader websocket.Upgrader
+	acks     chan socketmode.Ack
+
+	mu          sync.Mutex
+	conn        *websocket.Conn
+	connected   chan struct{}
+	connections int
+}
+
+// NewServer starts a Server.
+func NewServer() *Server {
+	s := &Server{
+		acks:      make(chan socketmode.Ack, 16),
+		connected: make(chan struct{}),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/apps.connections.open", s.handleOpen)
+	mux.HandleFunc("/link", s.handleLink)
+	s.server = httptest.NewServer(mux)
+
+	return s
+}
+
+// APIURL returns the URL to pass to socketmode.WithAPIURL.
+func (s *Server) APIURL() string {
+	return s.server.URL + "/"
+}
+
+// Close shuts the server down.
+func (s *Server) Close() {
+	s.mu.Lock()
+	if s.conn != nil {
+		s.conn.Close()
+	}
+	s.mu.Unlock()
+	s.server.Close()
+}
+
+func (s *Server) handleOpen(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer xapp-") {
+		json.NewEncoder(w).Encode(map[string]interface{}{"ok": false, "error": "invalid_auth"})
+		return
+	}
+
+	wsURL := "ws" + strings.TrimPrefix(s.server.URL, "http") + "/link"
+	json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "url": wsURL})
+}
+
+func (s *Server) handleLink(w http.ResponseWriter, r *http.Request) {
+	conn, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+
+	if err := conn.WriteJSON(socketmode.Envelope{Type: socketmode.TypeHello}); err != nil {
+		conn.Close()
+		return
+	}
+
+	s.mu.Lock()
+	s.conn = conn
+	s.connections++
+	close(s.connected)
+	s.connected = make(chan struct{})
+	s.mu.Unlock()
+
+	for {
+		var a socketmode.Ack
+		if err := conn.ReadJSON(&a); err != nil {
+			return
+		}
+		s.acks <- a
+	}
+}
+
+// WaitConnections waits until the client has connected n times in total.
+func (s *Server) WaitConnections(n int, timeout time.Duration) error {
+	deadline := time.After(timeout)
+	for {
+		s.mu.Lock()
+		connections, connected := s.connections, s.connected
+		s.mu.Unlock()
+
+		if connections >= n {
+			return nil
+		}
+
+		select {
+		case <-connected:
+		case <-deadline:
+			return errors.New("timed out waiting for a connection")
+		}
+	}
+}
+
+// Send sends an envelope to the current connection.
+func (s *Server) Send(env socketmode.Envelope) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil {
+		return errors.New("no connection")
+	}
+	return s.conn.WriteJSON(env)
+}
+
+// Disconnect asks the client to reconnect like Slack does before refreshing a connection.
+func (s *Server) Disconnect(reason string) error {
+	return s.Send(socketmode.Envelope{Type: socketmode.TypeDisconnect, Reason: reason})
+}
+
+// Drop closes the current connection without notice.
+func (s *Server) Drop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn != nil {
+		s.conn.Close()
+		s.conn = nil
+	}
+}
+
+// Ack waits for the next ack from the client.
+func (s *Server) Ack(timeout time.Duration) (socketmode.Ack, error) {
+	select {
+	case a := <-s.acks:
+		return a, nil
+	case <-time.After(timeout):
+		return socketmode.Ack{}, errors.New("timed out waiting for an ack")
+	}
+}
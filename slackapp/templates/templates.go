@@ -0,0 +1,156 @@
+// Package templates renders modals and messages from Block Kit JSON written as text/template files,
+// so that the appearance of a view can be edited without touching the Go code that fills it.
+//
+// The templates in a directory are parsed once at startup, and every rendered view is checked with the
+// blockkit package before it's returned. A template named "order_modal" lives in
+// order_modal.json.tmpl. It can call the json function to write a value as a JSON literal, and the
+// escape function to write a string inside a JSON string literal:
+//
+//	{"type": "plain_text", "text": {{json .Shop.Title}}}
+//	{"type": "mrkdwn", "text": "*{{escape .Item.Name}}*\n{{escape .Item.Price}}"}
+package templates
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/nicoJN/slack-modal-examples/slackapp/blockkit"
+	"github.com/slack-go/slack"
+)
+
+// Ext is the extension of template files.
+const Ext = ".json.tmpl"
+
+// Set is the templates loaded from a directory.
+type Set struct {
+	templates map[string]*template.Template
+}
+
+// funcs are the functions templates can call.
+var funcs = template.FuncMap{
+	// json writes a value as a JSON literal, e.g. a quoted and escaped string.
+	"json": func(v interface{}) (string, error) {
+		b, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		return string(b), nil
+	},
+	// escape writes a string escaped as in a JSON string literal, without the quotes.
+	"escape": func(s string) (string, error) {
+		b, err := json.Marshal(s)
+		if err != nil {
+			return "", err
+		}
+		return string(b[1 : len(b)-1]), nil
+	},
+}
+
+// Load parses the template files in dir.
+func Load(dir string) (*Set, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*"+Ext))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list templates: %w", err)
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no templates in %s", dir)
+	}
+
+	files := map[string]string{}
+	for _, path := range paths {
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template: %w", err)
+		}
+		files[strings.TrimSuffix(filepath.Base(path), Ext)] = string(b)
+	}
+	return Parse(files)
+}
+
+// Parse parses templates keyed by name.
+func Parse(files map[string]string) (*Set, error) {
+	s := &Set{templates: map[string]*template.Template{}}
+	for name, text := range files {
+		t, err := template.New(name).Funcs(funcs).Option("missingkey=error").Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse template: %w", err)
+		}
+		s.templates[name] = t
+	}
+	return s, nil
+}
+
+// Names returns the names of the templates in alphabetical order.
+func (s *Set) Names() []string {
+	var names []string
+	for name := range s.templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Has reports whether the set has a template named name.
+func (s *Set) Has(name string) bool {
+	_, ok := s.templates[name]
+	return ok
+}
+
+// render executes a template and checks the result with check.
+func (s *Set) render(name string, data interface{}, check func(interface{}) error) ([]byte, error) {
+	t, ok := s.templates[name]
+	if !ok {
+		return nil, fmt.Errorf("no template named %s", name)
+	}
+
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to render %s: %w", name, err)
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(buf.Bytes(), &v); err != nil {
+		return nil, fmt.Errorf("%s doesn't render JSON: %w", name, err)
+	}
+	if err := check(v); err != nil {
+		return nil, fmt.Errorf("%s doesn't render valid Block Kit: %w", name, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// Modal renders a modal from the template named name.
+func (s *Set) Modal(name string, data interface{}) (*slack.ModalViewRequest, error) {
+	b, err := s.render(name, data, blockkit.ValidateDecodedView)
+	if err != nil {
+		return nil, err
+	}
+
+	var modal slack.ModalViewRequest
+	if err := json.Unmarshal(b, &modal); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s: %w", name, err)
+	}
+	return &modal, nil
+}
+
+// Message renders the blocks of a message from the template named name, which renders an object with
+// a blocks array.
+func (s *Set) Message(name string, data interface{}) ([]slack.Block, error) {
+	b, err := s.render(name, data, blockkit.ValidateDecodedMessage)
+	if err != nil {
+		return nil, err
+	}
+
+	var message struct {
+		Blocks slack.Blocks `json:"blocks"`
+	}
+	if err := json.Unmarshal(b, &message); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s: %w", name, err)
+	}
+	return message.Blocks.BlockSet, nil
+}
@@ -0,0 +1,146 @@
+package templates_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nicoJN/slack-modal-examples/slackapp/templates"
+	"github.com/slack-go/slack"
+)
+
+const modal = `{
+	"type": "modal",
+	"title": {"type": "plain_text", "text": {{json .Title}}},
+	"callback_id": "order",
+	"blocks": [
+		{"type": "section", "text": {"type": "mrkdwn", "text": "*{{escape .Name}}*\n{{escape .Price}}"}},
+		{
+			"type": "input",
+			"block_id": "block_id_size",
+			"label": {"type": "plain_text", "text": "Size"},
+			"element": {
+				"type": "static_select",
+				"action_id": "action_id_size",
+				"options": [{"text": {"type": "plain_text", "text": "Large"}, "value": "l"}]
+			}
+		}
+	]
+}`
+
+type data struct {
+	Title, Name, Price string
+}
+
+func TestLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "templates")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"order.json.tmpl":   modal,
+		"receipt.json.tmpl": `{"blocks": [{"type": "divider"}]}`,
+		"README.md":         "not a template",
+	}
+	for name, text := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(text), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s, err := templates.Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(s.Names(), ","); got != "order,receipt" {
+		t.Errorf("names = %s, want order,receipt", got)
+	}
+
+	if _, err := templates.Load(filepath.Join(dir, "missing")); err == nil {
+		t.Error("expected an error for a directory without templates")
+	}
+}
+
+func TestModal(t *testing.T) {
+	s, err := templates.Parse(map[string]string{"order": modal})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Values are escaped, so quotes and newlines can't break the JSON.
+	m, err := s.Modal("order", data{Title: `"Burgers"`, Name: "Cheese\nBurger", Price: "$ 7.00"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Title.Text != `"Burgers"` || m.CallbackID != "order" || len(m.Blocks.BlockSet) != 2 {
+		t.Errorf("modal = %+v, want a modal titled \"Burgers\" with 2 blocks", m)
+	}
+	section := m.Blocks.BlockSet[0].(*slack.SectionBlock)
+	if want := "*Cheese\nBurger*\n$ 7.00"; section.Text.Text != want {
+		t.Errorf("section text = %q, want %q", section.Text.Text, want)
+	}
+	if _, ok := m.Blocks.BlockSet[1].(*slack.InputBlock).Element.(*slack.SelectBlockElement); !ok {
+		t.Errorf("input element = %+v, want a static_select", m.Blocks.BlockSet[1])
+	}
+
+	if _, err := s.Modal("missing", nil); err == nil {
+		t.Error("expected an error for a missing template")
+	}
+	if _, err := s.Modal("order", struct{ Title string }{"Burgers"}); err == nil {
+		t.Error("expected an error for data without a field the template uses")
+	}
+}
+
+func TestMessage(t *testing.T) {
+	s, err := templates.Parse(map[string]string{
+		"receipt": `{"blocks": [{{range $i, $item := .}}{{if $i}},{{end}}{"type": "section", "text": {"type": "mrkdwn", "text": {{json $item}}}}{{end}}]}`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	blocks, err := s.Message("receipt", []string{"Hamburger × 1", "Total: $ 5.00"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(blocks) != 2 || blocks[1].(*slack.SectionBlock).Text.Text != "Total: $ 5.00" {
+		t.Errorf("blocks = %+v, want 2 sections", blocks)
+	}
+}
+
+func TestSchema(t *testing.T) {
+	tests := []struct {
+		name string
+		view string
+		want string
+	}{
+		{"not JSON", `{"type": "modal",}`, "doesn't render JSON"},
+		{"not a modal", `{"type": "home", "blocks": []}`, "isn't modal"},
+		{"no title", `{"type": "modal", "blocks": []}`, "title: missing"},
+		{"mrkdwn title", `{"type": "modal", "title": {"type": "mrkdwn", "text": "Shop"}, "blocks": []}`, `title.type: "mrkdwn" isn't plain_text`},
+		{"unknown block", `{"type": "modal", "title": {"type": "plain_text", "text": "Shop"}, "blocks": [{"type": "header"}]}`, `blocks[0].type: "header"`},
+		{"typo", `{"type": "modal", "title": {"type": "plain_text", "text": "Shop"}, "blocks": [{"type": "divider", "blockid": "x"}]}`, "unknown field blockid"},
+		{"empty text", `{"type": "modal", "title": {"type": "plain_text", "text": "Shop"}, "blocks": [{"type": "section", "text": {"type": "mrkdwn", "text": ""}}]}`, "blocks[0].text.text: empty"},
+		{"button in an input", `{"type": "modal", "title": {"type": "plain_text", "text": "Shop"}, "blocks": [{"type": "input", "label": {"type": "plain_text", "text": "Size"}, "element": {"type": "button", "text": {"type": "plain_text", "text": "Add"}}}]}`, "element.type"},
+		{"option without value", `{"type": "modal", "title": {"type": "plain_text", "text": "Shop"}, "blocks": [{"type": "actions", "elements": [{"type": "radio_buttons", "options": [{"text": {"type": "plain_text", "text": "S"}}]}]}]}`, "elements[0].options[0].value: missing"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := templates.Parse(map[string]string{"view": tt.view})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := s.Modal("view", nil); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want an error about %q", err, tt.want)
+			}
+		})
+	}
+
+	if _, err := templates.Parse(map[string]string{"broken": `{{if}}`}); err == nil {
+		t.Error("expected an error for a template which doesn't parse")
+	}
+}
@@ -0,0 +1,263 @@
+// Package validate checks the inputs of a view_submission against rules declared for each input block,
+// and reports every failing block at once with a response_action of errors.
+//
+//	form := validate.NewForm().
+//		Field("block_id_age", "action_id_age", validate.Required(), validate.Numeric(), validate.Min("18")).
+//		Field("block_id_note", "action_id_note", validate.MaxLength(500))
+//
+// Values are trimmed before they're checked, and an empty value passes every rule except Required,
+// so optional inputs only need to be valid when they're filled in.
+package validate
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/nicoJN/slack-modal-examples/slackapp"
+	"github.com/slack-go/slack"
+)
+
+// Values are the input values of a view, keyed by block ID and action ID like View.State.Values.
+type Values map[string]map[string]slack.BlockAction
+
+// Get returns the trimmed value of an input.
+// Selects give the value of the selected option, and multi selects and checkboxes the values of the
+// selected options joined with commas.
+func (v Values) Get(blockID, actionID string) string {
+	a := v[blockID][actionID]
+
+	value := a.Value
+	switch {
+	case a.SelectedOption.Value != "":
+		value = a.SelectedOption.Value
+	case len(a.SelectedOptions) > 0:
+		var selected []string
+		for _, o := range a.SelectedOptions {
+			selected = append(selected, o.Value)
+		}
+		value = strings.Join(selected, ",")
+	case a.SelectedDate != "":
+		value = a.SelectedDate
+	}
+	return strings.TrimSpace(value)
+}
+
+// Rule checks the value of an input.
+type Rule struct {
+	check   func(value string) bool
+	message string
+	empty   bool // Also checks empty values.
+}
+
+// Check returns a rule which accepts the values check returns true for, and otherwise shows message.
+func Check(message string, check func(value string) bool) Rule {
+	return Rule{check: check, message: message}
+}
+
+// WithMessage returns a copy of r which shows message when the value is invalid.
+func (r Rule) WithMessage(message string) Rule {
+	r.message = message
+	return r
+}
+
+// Required rejects an empty value.
+func Required() Rule {
+	return Rule{
+		check:   func(v string) bool { return v != "" },
+		message: "This field is required.",
+		empty:   true,
+	}
+}
+
+// numeric matches plain decimal numbers. Unlike strconv.ParseFloat, it rejects "NaN", "Inf" and "1e9".
+var numeric = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?$`)
+
+// Numeric accepts a decimal number like "12", "-3" or "1.50".
+func Numeric() Rule {
+	return Check("Enter a number.", numeric.MatchString)
+}
+
+// Decimals accepts a decimal number with n decimal places at most.
+func Decimals(n int) Rule {
+	message := fmt.Sprintf("Use at most %d decimal places.", n)
+	if n == 0 {
+		message = "Enter a whole number."
+	}
+
+	return Check(message, func(v string) bool {
+		if !numeric.MatchString(v) {
+			return false
+		}
+		i := strings.IndexByte(v, '.')
+		return i < 0 || len(v)-i-1 <= n
+	})
+}
+
+// Min accepts a decimal number which is min or more. min is a decimal number like "0" or "1.50",
+// and numbers are compared exactly.
+func Min(min string) Rule {
+	bound := rat(min)
+	return Check("Enter "+min+" or more.", func(v string) bool {
+		x, ok := parseRat(v)
+		return ok && x.Cmp(bound) >= 0
+	})
+}
+
+// Max accepts a decimal number which is max or less. max is a decimal number like "100" or "7.50",
+// and numbers are compared exactly.
+func Max(max string) Rule {
+	bound := rat(max)
+	return Check("Enter "+max+" or less.", func(v string) bool {
+		x, ok := parseRat(v)
+		return ok && x.Cmp(bound) <= 0
+	})
+}
+
+// Range accepts a decimal number from min to max.
+func Range(min, max string) Rule {
+	lower, upper := Min(min), Max(max)
+	return Check(fmt.Sprintf("Enter a number from %s to %s.", min, max), func(v string) bool {
+		return lower.check(v) && upper.check(v)
+	})
+}
+
+// Regexp accepts a value which matches re.
+func Regexp(re *regexp.Regexp, message string) Rule {
+	return Check(message, re.MatchString)
+}
+
+// MaxLength accepts a value with n characters at most.
+func MaxLength(n int) Rule {
+	return Check(fmt.Sprintf("Use %d characters or fewer.", n), func(v string) bool {
+		return utf8.RuneCountInString(v) <= n
+	})
+}
+
+// OneOf accepts one of values, e.g. the options of a select.
+func OneOf(values ...string) Rule {
+	return Check("Choose one of the options.", func(v string) bool {
+		for _, value := range values {
+			if v == value {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// rat parses a bound of Min or Max. An invalid bound is a programming error.
+func rat(s string) *big.Rat {
+	x, ok := parseRat(s)
+	if !ok {
+		panic(fmt.Sprintf("validate: invalid bound %q", s))
+	}
+	return x
+}
+
+func parseRat(s string) (*big.Rat, bool) {
+	if !numeric.MatchString(s) {
+		return nil, false
+	}
+	return new(big.Rat).SetString(s)
+}
+
+type field struct {
+	blockID  string
+	actionID string
+	rules    []Rule
+}
+
+type crossCheck struct {
+	blockID string
+	check   func(Values) string
+}
+
+// Form declares the rules of the input blocks of a view.
+type Form struct {
+	fields []field
+	checks []crossCheck
+}
+
+// NewForm returns a Form without rules.
+func NewForm() *Form {
+	return &Form{}
+}
+
+// Field declares the rules of an input. The rules are checked in order, and the first failing one
+// is reported for the block. Declaring more rules of the same input adds them after the others.
+func (f *Form) Field(blockID, actionID string, rules ...Rule) *Form {
+	f.fields = append(f.fields, field{blockID: blockID, actionID: actionID, rules: rules})
+	return f
+}
+
+// CrossCheck declares a rule over several inputs, e.g. that only one of two inputs is filled in.
+// check returns a message which is shown under blockID, or "" when the values are valid.
+// It's skipped when the block already has an error of its own rules.
+func (f *Form) CrossCheck(blockID string, check func(Values) string) *Form {
+	f.checks = append(f.checks, crossCheck{blockID: blockID, check: check})
+	return f
+}
+
+// Validate returns the error messages keyed by block ID, or nil when every input is valid.
+func (f *Form) Validate(state *slack.ViewState) map[string]string {
+	values := Values{}
+	if state != nil {
+		values = state.Values
+	}
+
+	errors := map[string]string{}
+	for _, field := range f.fields {
+		if _, ok := errors[field.blockID]; ok {
+			continue
+		}
+		v := values.Get(field.blockID, field.actionID)
+		for _, r := range field.rules {
+			if v == "" && !r.empty {
+				continue
+			}
+			if !r.check(v) {
+				errors[field.blockID] = r.message
+				break
+			}
+		}
+	}
+
+	for _, c := range f.checks {
+		if _, ok := errors[c.blockID]; ok {
+			continue
+		}
+		if message := c.check(values); message != "" {
+			errors[c.blockID] = message
+		}
+	}
+	if len(errors) > 0 {
+		return errors
+	}
+	return nil
+}
+
+// Submission returns a handler of view_submission which validates the inputs with the form returned by
+// form before calling next. When some inputs are invalid, it shows the errors in the view instead.
+// form receives the submission, e.g. to read the private metadata.
+func Submission(form func(ctx context.Context, message slack.InteractionCallback) (*Form, error), next slackapp.InteractionHandler) slackapp.InteractionHandler {
+	return func(ctx context.Context, message slack.InteractionCallback) (events.APIGatewayProxyResponse, error) {
+		f, err := form(ctx, message)
+		if err != nil {
+			return slackapp.OK(), err
+		}
+
+		if errors := f.Validate(message.View.State); errors != nil {
+			res, err := slackapp.JSON(slack.NewErrorsViewSubmissionResponse(errors))
+			if err != nil {
+				return res, fmt.Errorf("failed to create a validation failed message: %w", err)
+			}
+			return res, nil
+		}
+		return next(ctx, message)
+	}
+}
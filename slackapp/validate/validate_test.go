@@ -0,0 +1,122 @@
+package validate_test
+
+import (
+	"context"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/nicoJN/slack-modal-examples/slackapp"
+	"github.com/nicoJN/slack-modal-examples/slackapp/slacktest"
+	"github.com/nicoJN/slack-modal-examples/slackapp/validate"
+	"github.com/slack-go/slack"
+)
+
+func TestRules(t *testing.T) {
+	tests := []struct {
+		name  string
+		rule  validate.Rule
+		valid []string
+		bad   []string
+	}{
+		{"numeric", validate.Numeric(), []string{"12", "-3", "1.50"}, []string{"a lot", "NaN", "Inf", "1e9", "1.", ".5", "+1", "1,000"}},
+		{"decimals", validate.Decimals(2), []string{"1", "1.5", "1.50"}, []string{"1.505", "x"}},
+		{"whole", validate.Decimals(0), []string{"150"}, []string{"1.5"}},
+		{"min", validate.Min("0"), []string{"0", "0.01", "99999999999999999999"}, []string{"-0.01", "x"}},
+		{"max", validate.Max("700.00"), []string{"700", "-1"}, []string{"700.01", "99999999999999999999"}},
+		{"range", validate.Range("1", "10"), []string{"1", "10"}, []string{"0.99", "10.5"}},
+		{"regexp", validate.Regexp(regexp.MustCompile(`^[A-Z]{3}$`), "Enter a code."), []string{"USD"}, []string{"usd", "USDX"}},
+		{"max length", validate.MaxLength(3), []string{"abc", "寿司で"}, []string{"abcd"}},
+		{"one of", validate.OneOf("10", "15"), []string{"15"}, []string{"50"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, v := range tt.valid {
+				if errors := form(tt.rule).Validate(state(v)); errors != nil {
+					t.Errorf("%q: errors = %v, want none", v, errors)
+				}
+			}
+			for _, v := range tt.bad {
+				if errors := form(tt.rule).Validate(state(v)); errors["block_id_x"] == "" {
+					t.Errorf("%q: no error", v)
+				}
+			}
+		})
+	}
+}
+
+func form(rules ...validate.Rule) *validate.Form {
+	return validate.NewForm().Field("block_id_x", "action_id_x", rules...)
+}
+
+func state(v string) *slack.ViewState {
+	return &slack.ViewState{Values: slacktest.Text("block_id_x", "action_id_x", v)}
+}
+
+func TestEmptyValues(t *testing.T) {
+	// Optional inputs pass when they're left empty.
+	if errors := form(validate.Numeric(), validate.Min("1")).Validate(state("  ")); errors != nil {
+		t.Errorf("errors = %v, want none", errors)
+	}
+
+	errors := form(validate.Required(), validate.Numeric()).Validate(nil)
+	if want := map[string]string{"block_id_x": "This field is required."}; !reflect.DeepEqual(errors, want) {
+		t.Errorf("errors = %v, want %v", errors, want)
+	}
+}
+
+func TestAllErrorsAtOnce(t *testing.T) {
+	f := validate.NewForm().
+		Field("block_id_size", "action_id_size", validate.Required()).
+		Field("block_id_amount", "action_id_amount", validate.Numeric().WithMessage("Enter an amount."), validate.Min("0")).
+		Field("block_id_note", "action_id_note", validate.MaxLength(5)).
+		CrossCheck("block_id_note", func(v validate.Values) string {
+			if v.Get("block_id_amount", "action_id_amount") != "" && v.Get("block_id_note", "action_id_note") != "" {
+				return "Not both."
+			}
+			return ""
+		})
+
+	values := slacktest.Values(
+		slacktest.Text("block_id_amount", "action_id_amount", "-1e2"),
+		slacktest.Text("block_id_note", "action_id_note", "hi"),
+	)
+	errors := f.Validate(&slack.ViewState{Values: values})
+
+	want := map[string]string{
+		"block_id_size":   "This field is required.",
+		"block_id_amount": "Enter an amount.",
+		"block_id_note":   "Not both.",
+	}
+	if !reflect.DeepEqual(errors, want) {
+		t.Errorf("errors = %v, want %v", errors, want)
+	}
+}
+
+func TestSubmission(t *testing.T) {
+	called := false
+	next := func(ctx context.Context, message slack.InteractionCallback) (events.APIGatewayProxyResponse, error) {
+		called = true
+		return slackapp.OK(), nil
+	}
+	h := validate.Submission(func(context.Context, slack.InteractionCallback) (*validate.Form, error) {
+		return form(validate.Required()), nil
+	}, next)
+
+	res, err := h(context.Background(), slacktest.ViewSubmission("U0001", slack.ModalViewRequest{}, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := slacktest.DecodeViewSubmissionResponse(res)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if called || r.ResponseAction != slack.RAErrors || r.Errors["block_id_x"] == "" {
+		t.Errorf("response = %+v, called = %v, want errors without calling next", r, called)
+	}
+
+	if _, err := h(context.Background(), slacktest.ViewSubmission("U0001", slack.ModalViewRequest{}, slacktest.Text("block_id_x", "action_id_x", "ok"))); err != nil || !called {
+		t.Errorf("err = %v, called = %v, want next to be called", err, called)
+	}
+}
@@ -0,0 +1,120 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/nicoJN/slack-modal-examples/slackapp/logging"
+)
+
+// ErrClosed is returned when a job is enqueued to a closed queue.
+var ErrClosed = errors.New("queue is closed")
+
+// ErrFull is returned when a job is enqueued to a queue which has no room left.
+var ErrFull = errors.New("queue is full")
+
+// inProcessCapacity is the number of jobs an InProcess holds while its workers are busy.
+const inProcessCapacity = 64
+
+// InProcess runs jobs in goroutines of this process.
+// It suits a long-running process like the standalone server. On Lambda, the process is frozen
+// once the handler returns, so use LambdaQueue instead.
+type InProcess struct {
+	runner  Runner
+	timeout time.Duration
+
+	mu     sync.RWMutex
+	closed bool
+	jobs   chan inProcessJob
+	wg     sync.WaitGroup
+	active sync.WaitGroup
+}
+
+type inProcessJob struct {
+	job    Job
+	logger *logging.Logger
+}
+
+// NewInProcess starts workers which run jobs with runner. Each job runs with a deadline of timeout.
+func NewInProcess(runner Runner, workers int, timeout time.Duration) *InProcess {
+	q := &InProcess{
+		runner:  runner,
+		timeout: timeout,
+		jobs:    make(chan inProcessJob, inProcessCapacity),
+	}
+
+	for i := 0; i < workers; i++ {
+		q.wg.Add(1)
+		go q.work()
+	}
+	return q
+}
+
+// Enqueue accepts a job. The job doesn't inherit the cancellation of ctx, because ctx usually ends
+// with the request, but it keeps the logger of ctx.
+// Enqueue never waits for room, so that the request is acknowledged in time. It returns ErrFull when
+// the workers are behind, or the error of ctx when it has already ended.
+func (q *InProcess) Enqueue(ctx context.Context, job Job) error {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	if q.closed {
+		return ErrClosed
+	}
+
+	q.active.Add(1)
+	select {
+	case q.jobs <- inProcessJob{job: job, logger: logging.FromContext(ctx)}:
+		return nil
+	case <-ctx.Done():
+		q.active.Done()
+		return ctx.Err()
+	default:
+		q.active.Done()
+		return ErrFull
+	}
+}
+
+func (q *InProcess) work() {
+	defer q.wg.Done()
+
+	for j := range q.jobs {
+		q.run(j)
+	}
+}
+
+func (q *InProcess) run(j inProcessJob) {
+	defer q.active.Done()
+
+	ctx, cancel := context.WithTimeout(logging.NewContext(context.Background(), j.logger), q.timeout)
+	defer cancel()
+
+	defer func() {
+		if p := recover(); p != nil {
+			logging.FromContext(ctx).Error("Recovered from panic in job", "job", j.job.Kind, "panic", p)
+		}
+	}()
+
+	if err := q.runner.Run(ctx, j.job); err != nil {
+		logging.FromContext(ctx).Error("Failed to run job", "job", j.job.Kind, logging.KeyError, err)
+	}
+}
+
+// Wait blocks until every job enqueued so far has finished.
+func (q *InProcess) Wait() {
+	q.active.Wait()
+}
+
+// Close stops accepting jobs and waits for the enqueued jobs to finish.
+func (q *InProcess) Close() {
+	q.mu.Lock()
+	if !q.closed {
+		q.closed = true
+		close(q.jobs)
+	}
+	q.mu.Unlock()
+
+	q.wg.Wait()
+}
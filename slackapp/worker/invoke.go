@@ -0,0 +1,42 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
+)
+
+// SDKInvoker invokes Lambda functions with the Lambda client of the AWS SDK.
+type SDKInvoker struct {
+	client lambdaiface.LambdaAPI
+}
+
+// NewSDKInvoker returns an SDKInvoker which invokes functions through client.
+func NewSDKInvoker(client lambdaiface.LambdaAPI) *SDKInvoker {
+	return &SDKInvoker{client: client}
+}
+
+// NewSDKInvokerFromEnv returns an SDKInvoker with the region and the credentials which the Lambda
+// runtime sets to the environment.
+func NewSDKInvokerFromEnv() (*SDKInvoker, error) {
+	sess, err := session.NewSession()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create an AWS session: %w", err)
+	}
+	return NewSDKInvoker(lambda.New(sess)), nil
+}
+
+// InvokeAsync invokes a function with the Event invocation type, so that it returns once Lambda
+// has queued the invocation.
+func (i *SDKInvoker) InvokeAsync(ctx context.Context, functionName string, payload []byte) error {
+	_, err := i.client.InvokeWithContext(ctx, &lambda.InvokeInput{
+		FunctionName:   aws.String(functionName),
+		InvocationType: aws.String(lambda.InvocationTypeEvent),
+		Payload:        payload,
+	})
+	return err
+}
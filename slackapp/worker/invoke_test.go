@@ -0,0 +1,46 @@
+package worker_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
+	"github.com/nicoJN/slack-modal-examples/slackapp/worker"
+)
+
+// fakeLambda records the invocations of the Lambda client.
+type fakeLambda struct {
+	lambdaiface.LambdaAPI
+	input *lambda.InvokeInput
+	err   error
+}
+
+func (f *fakeLambda) InvokeWithContext(ctx aws.Context, input *lambda.InvokeInput, opts ...request.Option) (*lambda.InvokeOutput, error) {
+	f.input = input
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &lambda.InvokeOutput{StatusCode: aws.Int64(202)}, nil
+}
+
+func TestSDKInvoker(t *testing.T) {
+	client := &fakeLambda{}
+	i := worker.NewSDKInvoker(client)
+
+	if err := i.InvokeAsync(context.Background(), "interactive", []byte(`{}`)); err != nil {
+		t.Fatal(err)
+	}
+	in := client.input
+	if aws.StringValue(in.FunctionName) != "interactive" || aws.StringValue(in.InvocationType) != lambda.InvocationTypeEvent || string(in.Payload) != "{}" {
+		t.Errorf("input = %v, want an Event invocation of interactive with {}", in)
+	}
+
+	client.err = errors.New("AccessDeniedException")
+	if err := i.InvokeAsync(context.Background(), "interactive", []byte(`{}`)); err == nil {
+		t.Error("expected an error for a rejected invocation")
+	}
+}
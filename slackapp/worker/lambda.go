@@ -0,0 +1,78 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/nicoJN/slack-modal-examples/slackapp"
+)
+
+// Invoker invokes a Lambda function asynchronously.
+type Invoker interface {
+	InvokeAsync(ctx context.Context, functionName string, payload []byte) error
+}
+
+// lambdaEnvelope is the payload of a self-invocation. The key tells it apart from API Gateway events.
+type lambdaEnvelope struct {
+	Job *Job `json:"slackapp_job,omitempty"`
+}
+
+// LambdaQueue runs jobs by invoking a Lambda function asynchronously, usually the function itself.
+// The function must be started with LambdaHandler so that it accepts both requests and jobs.
+type LambdaQueue struct {
+	invoker      Invoker
+	functionName string
+}
+
+// NewLambdaQueue returns a LambdaQueue which invokes functionName through invoker.
+func NewLambdaQueue(functionName string, invoker Invoker) *LambdaQueue {
+	return &LambdaQueue{invoker: invoker, functionName: functionName}
+}
+
+// NewSelfInvokeQueue returns a LambdaQueue which invokes the running function itself.
+// The function name, the region and the credentials are read from the Lambda runtime environment.
+func NewSelfInvokeQueue() (*LambdaQueue, error) {
+	functionName := os.Getenv("AWS_LAMBDA_FUNCTION_NAME")
+	if functionName == "" {
+		return nil, fmt.Errorf("AWS_LAMBDA_FUNCTION_NAME is not set, not running on Lambda?")
+	}
+
+	invoker, err := NewSDKInvokerFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return NewLambdaQueue(functionName, invoker), nil
+}
+
+// Enqueue invokes the function with the job.
+func (q *LambdaQueue) Enqueue(ctx context.Context, job Job) error {
+	payload, err := json.Marshal(lambdaEnvelope{Job: &job})
+	if err != nil {
+		return fmt.Errorf("failed to marshal job: %w", err)
+	}
+
+	if err := q.invoker.InvokeAsync(ctx, q.functionName, payload); err != nil {
+		return fmt.Errorf("failed to invoke %s: %w", q.functionName, err)
+	}
+	return nil
+}
+
+// LambdaHandler returns a Lambda handler which runs jobs enqueued by a LambdaQueue with runner,
+// and passes the other invocations to h as API Gateway requests.
+func LambdaHandler(h slackapp.Handler, runner Runner) func(ctx context.Context, payload json.RawMessage) (interface{}, error) {
+	return func(ctx context.Context, payload json.RawMessage) (interface{}, error) {
+		var envelope lambdaEnvelope
+		if err := json.Unmarshal(payload, &envelope); err == nil && envelope.Job != nil {
+			return nil, runner.Run(ctx, *envelope.Job)
+		}
+
+		var request events.APIGatewayProxyRequest
+		if err := json.Unmarshal(payload, &request); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal request: %w", err)
+		}
+		return h(ctx, request)
+	}
+}
@@ -0,0 +1,72 @@
+// Package worker runs slow work after a request has been acknowledged.
+//
+// Slack gives up on a request after 3 seconds and retries it, so handlers should only do what's
+// needed for the response, and enqueue the rest (e.g. calls to the Slack Web API) as jobs.
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/nicoJN/slack-modal-examples/slackapp"
+	"github.com/nicoJN/slack-modal-examples/slackapp/logging"
+)
+
+// Job is a unit of work. Payload is passed to the function registered for Kind.
+type Job struct {
+	Kind    string          `json:"kind"`
+	Payload json.RawMessage `json:"payload"`
+
+	// RequestID correlates the logs of the job with the request which enqueued it.
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// NewJob returns a job with payload marshaled as JSON.
+func NewJob(ctx context.Context, kind string, payload interface{}) (Job, error) {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return Job{}, fmt.Errorf("failed to marshal job payload: %w", err)
+	}
+	return Job{Kind: kind, Payload: b, RequestID: slackapp.RequestID(ctx)}, nil
+}
+
+// Func runs a job.
+type Func func(ctx context.Context, payload json.RawMessage) error
+
+// Runner runs jobs.
+type Runner interface {
+	Run(ctx context.Context, job Job) error
+}
+
+// Queue accepts jobs to run later.
+type Queue interface {
+	Enqueue(ctx context.Context, job Job) error
+}
+
+// Registry is a Runner which dispatches jobs to the functions registered for their kinds.
+type Registry struct {
+	funcs map[string]Func
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{funcs: map[string]Func{}}
+}
+
+// Register registers f for jobs of kind.
+func (r *Registry) Register(kind string, f Func) {
+	r.funcs[kind] = f
+}
+
+// Run runs a job with the function registered for its kind.
+func (r *Registry) Run(ctx context.Context, job Job) error {
+	f, ok := r.funcs[job.Kind]
+	if !ok {
+		return fmt.Errorf("unknown job kind: %s", job.Kind)
+	}
+
+	ctx = slackapp.WithRequestID(ctx, job.RequestID)
+	ctx = logging.With(ctx, logging.KeyRequestID, job.RequestID, "job", job.Kind)
+	return f(ctx, job.Payload)
+}
@@ -0,0 +1,161 @@
+package worker_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/nicoJN/slack-modal-examples/slackapp"
+	"github.com/nicoJN/slack-modal-examples/slackapp/worker"
+)
+
+func TestRegistry(t *testing.T) {
+	r := worker.NewRegistry()
+
+	var gotPayload, gotRequestID string
+	r.Register("greet", func(ctx context.Context, payload json.RawMessage) error {
+		gotPayload = string(payload)
+		gotRequestID = slackapp.RequestID(ctx)
+		return nil
+	})
+
+	ctx := slackapp.WithRequestID(context.Background(), "req-1")
+	job, err := worker.NewJob(ctx, "greet", map[string]string{"name": "gopher"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := r.Run(context.Background(), job); err != nil {
+		t.Fatal(err)
+	}
+	if gotPayload != `{"name":"gopher"}` {
+		t.Errorf("payload = %s", gotPayload)
+	}
+	if gotRequestID != "req-1" {
+		t.Errorf("request ID = %q, want req-1", gotRequestID)
+	}
+
+	if err := r.Run(context.Background(), worker.Job{Kind: "unknown"}); err == nil {
+		t.Error("expected an error for an unknown kind")
+	}
+}
+
+func TestInProcess(t *testing.T) {
+	r := worker.NewRegistry()
+
+	var mu sync.Mutex
+	var runs int
+	r.Register("count", func(ctx context.Context, payload json.RawMessage) error {
+		mu.Lock()
+		defer mu.Unlock()
+		runs++
+		return nil
+	})
+	r.Register("panic", func(ctx context.Context, payload json.RawMessage) error {
+		panic("boom")
+	})
+	r.Register("fail", func(ctx context.Context, payload json.RawMessage) error {
+		return errors.New("failed")
+	})
+
+	q := worker.NewInProcess(r, 2, time.Second)
+
+	// The job outlives the context of the request.
+	ctx, cancel := context.WithCancel(context.Background())
+	for _, kind := range []string{"count", "panic", "fail", "count"} {
+		if err := q.Enqueue(ctx, worker.Job{Kind: kind}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cancel()
+	q.Wait()
+
+	if runs != 2 {
+		t.Errorf("runs = %d, want 2", runs)
+	}
+
+	q.Close()
+	if err := q.Enqueue(context.Background(), worker.Job{Kind: "count"}); err != worker.ErrClosed {
+		t.Errorf("err = %v, want ErrClosed", err)
+	}
+}
+
+func TestInProcessFull(t *testing.T) {
+	r := worker.NewRegistry()
+	release := make(chan struct{})
+	r.Register("block", func(ctx context.Context, payload json.RawMessage) error {
+		<-release
+		return nil
+	})
+	q := worker.NewInProcess(r, 1, time.Second)
+
+	// Enqueue doesn't block the request while the worker is stuck.
+	var err error
+	for i := 0; i < 100 && err == nil; i++ {
+		err = q.Enqueue(context.Background(), worker.Job{Kind: "block"})
+	}
+	if err != worker.ErrFull {
+		t.Errorf("err = %v, want ErrFull", err)
+	}
+
+	close(release)
+	q.Close()
+}
+
+type fakeInvoker struct {
+	functionName string
+	payload      []byte
+}
+
+func (f *fakeInvoker) InvokeAsync(ctx context.Context, functionName string, payload []byte) error {
+	f.functionName = functionName
+	f.payload = payload
+	return nil
+}
+
+func TestLambdaQueue(t *testing.T) {
+	invoker := &fakeInvoker{}
+	q := worker.NewLambdaQueue("interactive", invoker)
+
+	r := worker.NewRegistry()
+	var ran bool
+	r.Register("job", func(ctx context.Context, payload json.RawMessage) error {
+		ran = string(payload) == `"payload"`
+		return nil
+	})
+
+	var requested bool
+	h := worker.LambdaHandler(func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		requested = request.Body == "body"
+		return slackapp.OK(), nil
+	}, r)
+
+	// A self-invocation runs the job.
+	if err := q.Enqueue(context.Background(), worker.Job{Kind: "job", Payload: json.RawMessage(`"payload"`)}); err != nil {
+		t.Fatal(err)
+	}
+	if invoker.functionName != "interactive" {
+		t.Errorf("function name = %q, want interactive", invoker.functionName)
+	}
+	if _, err := h(context.Background(), invoker.payload); err != nil {
+		t.Fatal(err)
+	}
+	if !ran || requested {
+		t.Errorf("ran = %v, requested = %v, want only the job to run", ran, requested)
+	}
+
+	// The others are requests from API Gateway.
+	ran = false
+	request, _ := json.Marshal(events.APIGatewayProxyRequest{HTTPMethod: "POST", Body: "body"})
+	res, err := h(context.Background(), request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.(events.APIGatewayProxyResponse).StatusCode != 200 || !requested || ran {
+		t.Errorf("ran = %v, requested = %v, want only the handler to run", ran, requested)
+	}
+}